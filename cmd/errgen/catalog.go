@@ -0,0 +1,206 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// defaultHTTPStatus is used for codes that do not declare an HTTP status
+const defaultHTTPStatus = http.StatusInternalServerError
+
+// defaultSeverity is used for categories that do not declare a severity
+const defaultSeverity = "error"
+
+// identifierPattern matches exported Go identifiers usable as constant names
+var identifierPattern = regexp.MustCompile(`^[A-Z][A-Za-z0-9_]*$`)
+
+// severityConstants maps catalog severities to the Severity constants of pkg/errors
+var severityConstants = map[string]string{
+	"debug":    "SeverityDebug",
+	"info":     "SeverityInfo",
+	"warn":     "SeverityWarn",
+	"error":    "SeverityError",
+	"critical": "SeverityCritical",
+}
+
+// Catalog is the YAML description of every error category and code
+type Catalog struct {
+	Categories []Category  `yaml:"categories"`
+	Codes      []CodeEntry `yaml:"codes"`
+}
+
+// Category describes a category, the code range it owns and the defaults of its codes
+type Category struct {
+	Name       string   `yaml:"name"`        // Canonical category name used by GetCodesByCategory
+	Constant   string   `yaml:"constant"`    // Go constant name, defaults to "Category" + the title-cased name
+	Title      string   `yaml:"title"`       // Heading used in comments and documentation
+	Summary    string   `yaml:"summary"`     // One-line summary of the category
+	Min        int      `yaml:"min"`         // First code of the range (inclusive)
+	Max        int      `yaml:"max"`         // Last code of the range (inclusive)
+	Aliases    []string `yaml:"aliases"`     // Alternative names accepted for the category
+	HTTPStatus int      `yaml:"http_status"` // Default HTTP status of the category's codes, defaults to 500
+	Severity   string   `yaml:"severity"`    // Severity of the category's codes, defaults to error
+	Retryable  bool     `yaml:"retryable"`   // Whether every code of the category is transient
+}
+
+// CodeEntry describes a single error code
+type CodeEntry struct {
+	Code        string `yaml:"code"`        // Numeric code value
+	Name        string `yaml:"name"`        // Go constant name
+	Category    string `yaml:"category"`    // Name of the owning category
+	Description string `yaml:"description"` // Human-readable description stored in CodeDetails
+	Comment     string `yaml:"comment"`     // Trailing comment of the constant, defaults to the description
+	HTTPStatus  int    `yaml:"http_status"` // HTTP status returned to API clients, defaults to the category status
+	Retryable   *bool  `yaml:"retryable"`   // Whether failures with this code are transient, defaults to the category
+	Severity    string `yaml:"severity"`    // How serious failures with this code are, defaults to the category severity
+}
+
+// loadCatalog reads, validates and normalizes a catalog file
+func loadCatalog(path string) (*Catalog, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read catalog: %w", err)
+	}
+	return parseCatalog(data)
+}
+
+// parseCatalog decodes catalog YAML, validates it and fills in defaults
+func parseCatalog(data []byte) (*Catalog, error) {
+	var catalog Catalog
+	if err := yaml.Unmarshal(data, &catalog); err != nil {
+		return nil, fmt.Errorf("failed to parse catalog: %w", err)
+	}
+	if err := catalog.validate(); err != nil {
+		return nil, err
+	}
+	return &catalog, nil
+}
+
+// validate checks the catalog for the same rules the runtime registry enforces
+// so that a bad catalog fails at generation time instead of at startup
+func (c *Catalog) validate() error {
+	categories := make(map[string]Category, len(c.Categories))
+	constants := make(map[string]bool, len(c.Categories))
+	for i := range c.Categories {
+		cat := &c.Categories[i]
+		if cat.Name == "" {
+			return fmt.Errorf("category #%d has no name", i+1)
+		}
+		if cat.Min > cat.Max {
+			return fmt.Errorf("category %q has an invalid range %d-%d", cat.Name, cat.Min, cat.Max)
+		}
+		if cat.Constant == "" {
+			cat.Constant = "Category" + strings.ToUpper(cat.Name[:1]) + cat.Name[1:]
+		}
+		if !identifierPattern.MatchString(cat.Constant) {
+			return fmt.Errorf("category %q has an invalid constant name %q", cat.Name, cat.Constant)
+		}
+		if constants[cat.Constant] {
+			return fmt.Errorf("category constant name %s is used twice", cat.Constant)
+		}
+		constants[cat.Constant] = true
+		if cat.HTTPStatus == 0 {
+			cat.HTTPStatus = defaultHTTPStatus
+		}
+		if cat.HTTPStatus < 100 || cat.HTTPStatus > 599 {
+			return fmt.Errorf("category %q has an invalid HTTP status %d", cat.Name, cat.HTTPStatus)
+		}
+		if cat.Severity == "" {
+			cat.Severity = defaultSeverity
+		}
+		if _, ok := severityConstants[cat.Severity]; !ok {
+			return fmt.Errorf("category %q has an unknown severity %q", cat.Name, cat.Severity)
+		}
+		for _, name := range append([]string{cat.Name}, cat.Aliases...) {
+			if _, exists := categories[name]; exists {
+				return fmt.Errorf("category name %q is used twice", name)
+			}
+			categories[name] = *cat
+		}
+		for _, other := range c.Categories[:i] {
+			if cat.Min <= other.Max && cat.Max >= other.Min {
+				return fmt.Errorf("category %q overlaps category %q", cat.Name, other.Name)
+			}
+		}
+	}
+
+	codes := make(map[string]bool, len(c.Codes))
+	names := make(map[string]bool, len(c.Codes))
+	for i := range c.Codes {
+		entry := &c.Codes[i]
+		n, err := strconv.Atoi(entry.Code)
+		if err != nil {
+			return fmt.Errorf("code %q is not numeric", entry.Code)
+		}
+		if !identifierPattern.MatchString(entry.Name) {
+			return fmt.Errorf("code %s has an invalid constant name %q", entry.Code, entry.Name)
+		}
+		if codes[entry.Code] {
+			return fmt.Errorf("code %s is defined twice", entry.Code)
+		}
+		if names[entry.Name] {
+			return fmt.Errorf("constant name %s is used twice", entry.Name)
+		}
+		codes[entry.Code], names[entry.Name] = true, true
+
+		cat, ok := categories[entry.Category]
+		if !ok {
+			return fmt.Errorf("code %s references unknown category %q", entry.Code, entry.Category)
+		}
+		if n < cat.Min || n > cat.Max {
+			return fmt.Errorf("code %s is outside the %d-%d range of category %q", entry.Code, cat.Min, cat.Max, cat.Name)
+		}
+		entry.Category = cat.Name
+
+		if entry.Description == "" {
+			return fmt.Errorf("code %s has no description", entry.Code)
+		}
+		if entry.Comment == "" {
+			entry.Comment = entry.Description
+		}
+		if entry.HTTPStatus == 0 {
+			entry.HTTPStatus = cat.HTTPStatus
+		}
+		if entry.Retryable == nil {
+			retryable := cat.Retryable
+			entry.Retryable = &retryable
+		}
+		if entry.Severity == "" {
+			entry.Severity = cat.Severity
+		}
+		if _, ok := severityConstants[entry.Severity]; !ok {
+			return fmt.Errorf("code %s has an unknown severity %q", entry.Code, entry.Severity)
+		}
+		if entry.HTTPStatus < 100 || entry.HTTPStatus > 599 {
+			return fmt.Errorf("code %s has an invalid HTTP status %d", entry.Code, entry.HTTPStatus)
+		}
+	}
+	return nil
+}
+
+// categoryOf returns the category of a validated code entry
+func (c *Catalog) categoryOf(entry CodeEntry) (Category, bool) {
+	for _, cat := range c.Categories {
+		if cat.Name == entry.Category {
+			return cat, true
+		}
+	}
+	return Category{}, false
+}
+
+// codesOf returns the codes of a category in catalog order
+func (c *Catalog) codesOf(category string) []CodeEntry {
+	var codes []CodeEntry
+	for _, entry := range c.Codes {
+		if entry.Category == category {
+			codes = append(codes, entry)
+		}
+	}
+	return codes
+}
@@ -0,0 +1,175 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// validCategories is a catalog fragment shared by the validation tests
+const validCategories = `
+categories:
+  - name: payments
+    title: Payment Errors
+    summary: Payment processing issues
+    min: 5000
+    max: 5099
+    aliases: [pay]
+`
+
+// TestParseCatalog tests that defaults are applied to a valid catalog
+func TestParseCatalog(t *testing.T) {
+	catalog, err := parseCatalog([]byte(validCategories + `
+codes:
+  - code: "5000"
+    name: ErrCodePayment
+    category: pay
+    description: Payment failed
+  - code: "5001"
+    name: ErrCodeCardDeclined
+    category: payments
+    description: Card was declined
+    comment: Card declined by issuer
+    http_status: 402
+    retryable: true
+`))
+	require.NoError(t, err)
+	require.Len(t, catalog.Codes, 2)
+
+	first := catalog.Codes[0]
+	assert.Equal(t, "payments", first.Category, "aliases should resolve to the canonical category")
+	assert.Equal(t, "Payment failed", first.Comment, "comment should default to the description")
+	assert.Equal(t, defaultHTTPStatus, first.HTTPStatus)
+
+	second := catalog.Codes[1]
+	assert.Equal(t, "Card declined by issuer", second.Comment)
+	assert.Equal(t, 402, second.HTTPStatus)
+	assert.True(t, *second.Retryable)
+
+	category := catalog.Categories[0]
+	assert.Equal(t, "CategoryPayments", category.Constant, "constant should default to the title-cased name")
+	assert.Equal(t, defaultHTTPStatus, category.HTTPStatus)
+	assert.Equal(t, defaultSeverity, category.Severity)
+}
+
+// TestParseCatalogCategoryDefaults tests that codes inherit the defaults of their category
+func TestParseCatalogCategoryDefaults(t *testing.T) {
+	catalog, err := parseCatalog([]byte(`
+categories:
+  - {name: queue, constant: CategoryQueue, min: 6000, max: 6099, http_status: 503, severity: warn, retryable: true}
+codes:
+  - {code: "6000", name: ErrCodeQueueFull, category: queue, description: Queue is full}
+  - {code: "6001", name: ErrCodeQueueClosed, category: queue, description: Queue is closed, http_status: 410, severity: info}
+  - {code: "6002", name: ErrCodeQueueRejected, category: queue, description: Message was rejected, retryable: false}
+`))
+	require.NoError(t, err)
+
+	assert.Equal(t, 503, catalog.Codes[0].HTTPStatus)
+	assert.True(t, *catalog.Codes[0].Retryable)
+	assert.Equal(t, "warn", catalog.Codes[0].Severity)
+	assert.Equal(t, 410, catalog.Codes[1].HTTPStatus)
+	assert.True(t, *catalog.Codes[1].Retryable)
+	assert.Equal(t, "info", catalog.Codes[1].Severity)
+	assert.False(t, *catalog.Codes[2].Retryable, "a code can opt out of a retryable category")
+
+	code, err := generateCode(catalog, "queue", "codes.yaml")
+	require.NoError(t, err)
+	assert.Contains(t, string(code), `ErrCodeQueueFull:     {Name: "ErrCodeQueueFull", HTTPStatus: 503},`)
+	assert.Contains(t, string(code), `ErrCodeQueueClosed:   {Name: "ErrCodeQueueClosed", HTTPStatus: 410, Severity: SeverityInfo},`)
+	assert.Contains(t, string(code), `ErrCodeQueueRejected: {Name: "ErrCodeQueueRejected", HTTPStatus: 503, Retryable: Bool(false)},`)
+
+	test, err := generateTest(catalog, "queue", "codes.yaml")
+	require.NoError(t, err)
+	assert.Contains(t, string(test), `{"ErrCodeQueueRejected", ErrCodeQueueRejected, CategoryQueue, 6000, 6099, 503, false, SeverityWarn}`)
+}
+
+// TestParseCatalogValidation tests that invalid catalogs are rejected
+func TestParseCatalogValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		catalog string
+		errText string
+	}{
+		{
+			name:    "code outside range",
+			catalog: validCategories + "codes:\n  - {code: \"5100\", name: ErrCodeA, category: payments, description: a}\n",
+			errText: "outside the 5000-5099 range",
+		},
+		{
+			name:    "unknown category",
+			catalog: validCategories + "codes:\n  - {code: \"5000\", name: ErrCodeA, category: shipping, description: a}\n",
+			errText: "unknown category",
+		},
+		{
+			name: "duplicate code",
+			catalog: validCategories + "codes:\n  - {code: \"5000\", name: ErrCodeA, category: payments, description: a}\n" +
+				"  - {code: \"5000\", name: ErrCodeB, category: payments, description: b}\n",
+			errText: "defined twice",
+		},
+		{
+			name: "duplicate name",
+			catalog: validCategories + "codes:\n  - {code: \"5000\", name: ErrCodeA, category: payments, description: a}\n" +
+				"  - {code: \"5001\", name: ErrCodeA, category: payments, description: b}\n",
+			errText: "used twice",
+		},
+		{
+			name:    "non numeric code",
+			catalog: validCategories + "codes:\n  - {code: \"x1\", name: ErrCodeA, category: payments, description: a}\n",
+			errText: "not numeric",
+		},
+		{
+			name:    "invalid constant name",
+			catalog: validCategories + "codes:\n  - {code: \"5000\", name: errCodeA, category: payments, description: a}\n",
+			errText: "invalid constant name",
+		},
+		{
+			name:    "missing description",
+			catalog: validCategories + "codes:\n  - {code: \"5000\", name: ErrCodeA, category: payments}\n",
+			errText: "no description",
+		},
+		{
+			name:    "invalid http status",
+			catalog: validCategories + "codes:\n  - {code: \"5000\", name: ErrCodeA, category: payments, description: a, http_status: 42}\n",
+			errText: "invalid HTTP status",
+		},
+		{
+			name:    "unknown code severity",
+			catalog: validCategories + "codes:\n  - {code: \"5000\", name: ErrCodeA, category: payments, description: a, severity: loud}\n",
+			errText: "unknown severity",
+		},
+		{
+			name:    "unknown category severity",
+			catalog: validCategories + "  - {name: billing, min: 6000, max: 6099, severity: loud}\n",
+			errText: "unknown severity",
+		},
+		{
+			name:    "invalid category http status",
+			catalog: validCategories + "  - {name: billing, min: 6000, max: 6099, http_status: 42}\n",
+			errText: "invalid HTTP status",
+		},
+		{
+			name:    "duplicate category constant",
+			catalog: validCategories + "  - {name: billing, constant: CategoryPayments, min: 6000, max: 6099}\n",
+			errText: "used twice",
+		},
+		{
+			name:    "overlapping categories",
+			catalog: validCategories + "  - {name: billing, min: 5050, max: 5150}\n",
+			errText: "overlaps",
+		},
+		{
+			name:    "invalid yaml",
+			catalog: "categories: [",
+			errText: "failed to parse catalog",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseCatalog([]byte(tt.catalog))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errText)
+		})
+	}
+}
@@ -0,0 +1,201 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"net/http"
+	"strings"
+)
+
+// generateCode renders the constants, CodeDetails map and registry seed tables
+func generateCode(c *Catalog, pkg, source string) ([]byte, error) {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// Code generated by errgen from %s; DO NOT EDIT.\n\n", source)
+	fmt.Fprintf(&b, "package %s\n\n", pkg)
+
+	b.WriteString("// Built-in error categories, each owning a range of codes\n")
+	b.WriteString("const (\n")
+	for _, cat := range c.Categories {
+		fmt.Fprintf(&b, "\t%s Category = %q // %s (%d-%d)\n", cat.Constant, cat.Name, cat.Title, cat.Min, cat.Max)
+	}
+	b.WriteString(")\n\n")
+
+	b.WriteString("// Standardized error codes organized by category for consistent error handling\n")
+	b.WriteString("// Each category uses a specific number range to avoid conflicts and enable filtering\n")
+	b.WriteString("const (\n")
+	for i, cat := range c.Categories {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "\t// %s (%d-%d) - %s\n", cat.Title, cat.Min, cat.Max, cat.Summary)
+		for _, entry := range c.codesOf(cat.Name) {
+			fmt.Fprintf(&b, "\t%s Code = %q // %s\n", entry.Name, entry.Code, entry.Comment)
+		}
+	}
+	b.WriteString(")\n\n")
+
+	b.WriteString("// CodeDetails maps the built-in error codes to human-readable descriptions\n")
+	b.WriteString("// It seeds the code registry at startup; application codes are added with Register\n")
+	b.WriteString("var CodeDetails = map[Code]string{\n")
+	writeGrouped(&b, c, func(entry CodeEntry) string {
+		return fmt.Sprintf("\t%s: %q,\n", entry.Name, entry.Description)
+	})
+	b.WriteString("}\n\n")
+
+	b.WriteString("// builtinCategories lists the categories shipped with the library and the ranges they own\n")
+	b.WriteString("var builtinCategories = []builtinCategory{\n")
+	for _, cat := range c.Categories {
+		fields := fmt.Sprintf("name: %s, min: %d, max: %d", cat.Constant, cat.Min, cat.Max)
+		if len(cat.Aliases) > 0 {
+			quoted := make([]string, len(cat.Aliases))
+			for i, alias := range cat.Aliases {
+				quoted[i] = fmt.Sprintf("%q", alias)
+			}
+			fields += ", aliases: []string{" + strings.Join(quoted, ", ") + "}"
+		}
+		defaults := fmt.Sprintf("Severity: %s, HTTPStatus: %d", severityConstants[cat.Severity], cat.HTTPStatus)
+		if cat.Retryable {
+			defaults += ", Retryable: true"
+		}
+		fmt.Fprintf(&b, "\t{%s, defaults: CategoryDefaults{%s}},\n", fields, defaults)
+	}
+	b.WriteString("}\n\n")
+
+	b.WriteString("// builtinCodeOptions holds the registration options of the built-in codes\n")
+	b.WriteString("var builtinCodeOptions = map[Code]CodeOptions{\n")
+	writeGrouped(&b, c, func(entry CodeEntry) string {
+		opts := fmt.Sprintf("Name: %q, HTTPStatus: %d", entry.Name, entry.HTTPStatus)
+		// Only retryability and severities that differ from the category are spelled out
+		cat, _ := c.categoryOf(entry)
+		if *entry.Retryable != cat.Retryable {
+			opts += fmt.Sprintf(", Retryable: Bool(%t)", *entry.Retryable)
+		}
+		if entry.Severity != cat.Severity {
+			opts += ", Severity: " + severityConstants[entry.Severity]
+		}
+		return fmt.Sprintf("\t%s: {%s},\n", entry.Name, opts)
+	})
+	b.WriteString("}\n")
+
+	return format.Source(b.Bytes())
+}
+
+// writeGrouped writes one line per code, grouped under a comment per category
+func writeGrouped(b *bytes.Buffer, c *Catalog, line func(CodeEntry) string) {
+	for i, cat := range c.Categories {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(b, "\t// %s\n", cat.Title)
+		for _, entry := range c.codesOf(cat.Name) {
+			b.WriteString(line(entry))
+		}
+	}
+}
+
+// generateTest renders a table-driven test checking every code against its catalog entry
+func generateTest(c *Catalog, pkg, source string) ([]byte, error) {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// Code generated by errgen from %s; DO NOT EDIT.\n\n", source)
+	fmt.Fprintf(&b, "package %s\n\n", pkg)
+	b.WriteString(`import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestGeneratedCodeCatalog tests that every catalog code is registered in its category range
+func TestGeneratedCodeCatalog(t *testing.T) {
+	tests := []struct {
+		name       string
+		code       Code
+		category   Category
+		minRange   int
+		maxRange   int
+		httpStatus int
+		retryable  bool
+		severity   Severity
+	}{
+`)
+	for _, cat := range c.Categories {
+		for _, entry := range c.codesOf(cat.Name) {
+			fmt.Fprintf(&b, "\t\t{%q, %s, %s, %d, %d, %d, %t, %s},\n",
+				entry.Name, entry.Name, cat.Constant, cat.Min, cat.Max, entry.HTTPStatus, *entry.Retryable,
+				severityConstants[entry.Severity])
+		}
+	}
+	b.WriteString(`	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info, ok := LookupCode(tt.code)
+			require.True(t, ok, "Code %s should be registered", tt.code)
+			assert.Equal(t, tt.category, info.Category)
+			assert.Equal(t, tt.category, tt.code.Category())
+			assert.Equal(t, tt.name, info.Name)
+			assert.Equal(t, tt.httpStatus, info.HTTPStatus)
+			assert.Equal(t, tt.retryable, info.Retryable)
+			assert.Equal(t, tt.severity, info.Severity)
+			assert.Contains(t, GetCodesByCategory(string(tt.category)), tt.code)
+
+			codeNum, ok := parseCodeNumber(tt.code)
+			require.True(t, ok, "Code should be numeric: %s", tt.code)
+			assert.GreaterOrEqual(t, codeNum, tt.minRange, "Code %s should be >= %d", tt.code, tt.minRange)
+			assert.LessOrEqual(t, codeNum, tt.maxRange, "Code %s should be <= %d", tt.code, tt.maxRange)
+		})
+	}
+}
+`)
+	return format.Source(b.Bytes())
+}
+
+// generateDoc renders the Markdown error code reference
+func generateDoc(c *Catalog, source string) []byte {
+	var b bytes.Buffer
+	b.WriteString("# Error Code Reference\n\n")
+	fmt.Fprintf(&b, "<!-- Code generated by errgen from %s; DO NOT EDIT. -->\n\n", source)
+	b.WriteString("Every error code used by `pkg/errors`, grouped by category. ")
+	b.WriteString("Applications reserve their own ranges with `errors.ReserveRange` or `errors.ReserveCategory` ")
+	b.WriteString("and add codes with `errors.Register`.\n\n")
+
+	b.WriteString("## Categories\n\n")
+	b.WriteString("Codes inherit the severity, HTTP status and retryability of their category unless they set their own.\n\n")
+	b.WriteString("| Category | Constant | Range | Aliases | Severity | HTTP Status | Retryable | Summary |\n")
+	b.WriteString("|----------|----------|-------|---------|----------|-------------|-----------|---------|\n")
+	for _, cat := range c.Categories {
+		aliases := "-"
+		if len(cat.Aliases) > 0 {
+			aliases = "`" + strings.Join(cat.Aliases, "`, `") + "`"
+		}
+		fmt.Fprintf(&b, "| `%s` | `%s` | %d-%d | %s | %s | %d | %s | %s |\n",
+			cat.Name, cat.Constant, cat.Min, cat.Max, aliases, cat.Severity, cat.HTTPStatus, yesNo(cat.Retryable), cat.Summary)
+	}
+
+	for _, cat := range c.Categories {
+		fmt.Fprintf(&b, "\n## %s (%d-%d)\n\n", cat.Title, cat.Min, cat.Max)
+		fmt.Fprintf(&b, "%s. Category name: `%s` (`%s`).\n\n", cat.Summary, cat.Name, cat.Constant)
+		b.WriteString("| Code | Name | Description | Severity | HTTP Status | Retryable |\n")
+		b.WriteString("|------|------|-------------|----------|-------------|-----------|\n")
+		for _, entry := range c.codesOf(cat.Name) {
+			fmt.Fprintf(&b, "| %s | `%s` | %s | %s | %d %s | %s |\n",
+				entry.Code, entry.Name, escapeMarkdown(entry.Description), entry.Severity,
+				entry.HTTPStatus, http.StatusText(entry.HTTPStatus), yesNo(*entry.Retryable))
+		}
+	}
+	return b.Bytes()
+}
+
+// yesNo renders a flag for documentation tables
+func yesNo(b bool) string {
+	if b {
+		return "Yes"
+	}
+	return "No"
+}
+
+// escapeMarkdown escapes characters that would break a Markdown table cell
+func escapeMarkdown(s string) string {
+	return strings.ReplaceAll(s, "|", `\|`)
+}
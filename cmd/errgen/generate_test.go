@@ -0,0 +1,75 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// errorsPackageDir is the location of the catalog and generated files of pkg/errors
+const errorsPackageDir = "../../pkg/errors"
+
+// TestGeneratedFilesUpToDate tests that the committed files match the catalog
+// A failure means codes.yaml was edited without running go generate
+func TestGeneratedFilesUpToDate(t *testing.T) {
+	catalog, err := loadCatalog(filepath.Join(errorsPackageDir, "codes.yaml"))
+	require.NoError(t, err)
+
+	code, err := generateCode(catalog, "errors", "codes.yaml")
+	require.NoError(t, err)
+	test, err := generateTest(catalog, "errors", "codes.yaml")
+	require.NoError(t, err)
+
+	expected := map[string][]byte{
+		"error_codes_gen.go":      code,
+		"error_codes_gen_test.go": test,
+		"ERROR_CODES.md":          generateDoc(catalog, "codes.yaml"),
+	}
+	for name, content := range expected {
+		committed, err := os.ReadFile(filepath.Join(errorsPackageDir, name))
+		require.NoError(t, err)
+		assert.Equal(t, string(content), string(committed), "%s is out of date, run go generate ./pkg/errors", name)
+	}
+}
+
+// TestRun tests that every requested output is written
+func TestRun(t *testing.T) {
+	dir := t.TempDir()
+	catalogPath := filepath.Join(dir, "codes.yaml")
+	require.NoError(t, os.WriteFile(catalogPath, []byte(validCategories+`
+codes:
+  - code: "5000"
+    name: ErrCodePayment
+    category: payments
+    description: Payment | refund failed
+    retryable: true
+`), 0o600))
+
+	out := filepath.Join(dir, "codes_gen.go")
+	testOut := filepath.Join(dir, "codes_gen_test.go")
+	doc := filepath.Join(dir, "CODES.md")
+	require.NoError(t, run(catalogPath, "payments", out, testOut, doc))
+
+	code, err := os.ReadFile(out)
+	require.NoError(t, err)
+	assert.Contains(t, string(code), "// Code generated by errgen from codes.yaml; DO NOT EDIT.")
+	assert.Contains(t, string(code), "package payments")
+	assert.Contains(t, string(code), `ErrCodePayment Code = "5000" // Payment | refund failed`)
+	assert.Contains(t, string(code), `CategoryPayments Category = "payments"`)
+	assert.Contains(t, string(code), `{name: CategoryPayments, min: 5000, max: 5099, aliases: []string{"pay"}, defaults: CategoryDefaults{Severity: SeverityError, HTTPStatus: 500}}`)
+	assert.Contains(t, string(code), `ErrCodePayment: {Name: "ErrCodePayment", HTTPStatus: 500, Retryable: Bool(true)}`)
+
+	test, err := os.ReadFile(testOut)
+	require.NoError(t, err)
+	assert.Contains(t, string(test), `{"ErrCodePayment", ErrCodePayment, CategoryPayments, 5000, 5099, 500, true, SeverityError}`)
+
+	markdown, err := os.ReadFile(doc)
+	require.NoError(t, err)
+	assert.Contains(t, string(markdown), "## Payment Errors (5000-5099)")
+	assert.Contains(t, string(markdown), "| 5000 | `ErrCodePayment` | Payment \\| refund failed | error | 500 Internal Server Error | Yes |")
+
+	assert.Error(t, run(filepath.Join(dir, "missing.yaml"), "payments", out, "", ""))
+}
@@ -0,0 +1,63 @@
+// Command errgen generates the error code constants, registry tables, catalog test
+// and Markdown reference of pkg/errors from a YAML catalog
+//
+// It is run through go generate from pkg/errors:
+//
+//	go run ../../cmd/errgen -catalog codes.yaml -out error_codes_gen.go \
+//		-test-out error_codes_gen_test.go -doc ERROR_CODES.md
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+func main() {
+	catalogPath := flag.String("catalog", "codes.yaml", "path of the YAML error code catalog")
+	pkg := flag.String("package", "errors", "package name of the generated Go files")
+	out := flag.String("out", "", "output path of the generated Go source (skipped if empty)")
+	testOut := flag.String("test-out", "", "output path of the generated Go test (skipped if empty)")
+	doc := flag.String("doc", "", "output path of the generated Markdown reference (skipped if empty)")
+	flag.Parse()
+
+	if err := run(*catalogPath, *pkg, *out, *testOut, *doc); err != nil {
+		fmt.Fprintln(os.Stderr, "errgen:", err)
+		os.Exit(1)
+	}
+}
+
+// run loads the catalog and writes every requested output
+func run(catalogPath, pkg, out, testOut, doc string) error {
+	catalog, err := loadCatalog(catalogPath)
+	if err != nil {
+		return err
+	}
+	source := filepath.Base(catalogPath)
+
+	if out != "" {
+		code, err := generateCode(catalog, pkg, source)
+		if err != nil {
+			return fmt.Errorf("failed to format generated code: %w", err)
+		}
+		if err := os.WriteFile(out, code, 0o644); err != nil {
+			return err
+		}
+	}
+	if testOut != "" {
+		test, err := generateTest(catalog, pkg, source)
+		if err != nil {
+			return fmt.Errorf("failed to format generated test: %w", err)
+		}
+		if err := os.WriteFile(testOut, test, 0o644); err != nil {
+			return err
+		}
+	}
+	if doc != "" {
+		if err := os.WriteFile(doc, generateDoc(catalog, source), 0o644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package errors
+
+// Category groups related error codes under a reserved number range
+// The built-in categories are generated from codes.yaml; applications add their own
+// with ReserveRange or ReserveCategory
+type Category string
+
+// CategoryDefaults holds the values applied to codes of a category that do not set their own
+type CategoryDefaults struct {
+	Severity   Severity // Severity of the category's codes, SeverityError if unset
+	HTTPStatus int      // HTTP status returned to API clients, 500 if unset
+	Retryable  bool     // Whether failures in this category are transient and worth retrying
+}
+
+// CategoryInfo describes a reserved category
+type CategoryInfo struct {
+	Name     Category         // Canonical category name
+	Min      int              // First code of the range (inclusive)
+	Max      int              // Last code of the range (inclusive)
+	Aliases  []string         // Alternative names accepted for the category
+	Defaults CategoryDefaults // Defaults applied to the category's codes
+}
+
+// info converts a reserved range to its public description
+func (rg codeRange) info() CategoryInfo {
+	return CategoryInfo{
+		Name:     rg.category,
+		Min:      rg.min,
+		Max:      rg.max,
+		Aliases:  append([]string(nil), rg.aliases...),
+		Defaults: rg.defaults,
+	}
+}
+
+// String returns the category name
+func (c Category) String() string {
+	return string(c)
+}
+
+// Category returns the category the code belongs to, see CategoryOf
+func (c Code) Category() Category {
+	return CategoryOf(c)
+}
+
+// CategoryOf returns the category of a code
+// Unregistered codes inside a reserved range belong to that range's category;
+// an empty category is returned for codes outside every range
+func CategoryOf(code Code) Category {
+	category, _ := codeRegistry.categoryOf(code)
+	return category
+}
+
+// IsCategory reports whether any coded error in err's chain belongs to the category
+// The category may be given by name or alias (e.g. "db" for CategoryDatabase)
+func IsCategory(err error, category Category) bool {
+	codeRegistry.mu.RLock()
+	name, ok := codeRegistry.canonicalCategory(category)
+	codeRegistry.mu.RUnlock()
+	if !ok {
+		return false
+	}
+	return inCategory(err, name)
+}
+
+// inCategory walks the chain of err, including every member of multi-errors,
+// looking for a coded error of the canonical category
+// Multi-errors match only through their members, never by their computed aggregate code
+func inCategory(err error, category Category) bool {
+	for err != nil {
+		if multi, ok := err.(interface{ Unwrap() []error }); ok {
+			for _, member := range multi.Unwrap() {
+				if inCategory(member, category) {
+					return true
+				}
+			}
+			return false
+		}
+		if coded, ok := err.(Error); ok && CategoryOf(coded.Code()) == category {
+			return true
+		}
+		wrapper, ok := err.(interface{ Unwrap() error })
+		if !ok {
+			return false
+		}
+		err = wrapper.Unwrap()
+	}
+	return false
+}
+
+// LookupCategory returns the details of a category given by name or alias
+func LookupCategory(category Category) (CategoryInfo, bool) {
+	return codeRegistry.category(category)
+}
+
+// GetCategories returns every reserved category ordered by code range
+func GetCategories() []CategoryInfo {
+	return codeRegistry.categories()
+}
@@ -0,0 +1,95 @@
+package errors
+
+import (
+	stderrors "errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCategoryOf tests category resolution for registered and unregistered codes
+func TestCategoryOf(t *testing.T) {
+	tests := []struct {
+		name     string
+		code     Code
+		expected Category
+	}{
+		{name: "registered code", code: ErrCodeDBConnection, expected: CategoryDatabase},
+		{name: "base code", code: ErrCodeValidation, expected: CategoryValidation},
+		{name: "unregistered code inside a range", code: Code("1298"), expected: CategoryDatabase},
+		{name: "code outside every range", code: Code("99999"), expected: ""},
+		{name: "non numeric code", code: Code("abc"), expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, CategoryOf(tt.code))
+			assert.Equal(t, tt.expected, tt.code.Category())
+		})
+	}
+}
+
+// TestIsCategory tests category matching across error chains
+func TestIsCategory(t *testing.T) {
+	dbErr := NewErrDefault(ErrCodeDBNotFound, "user not found", "users")
+	multi := NewMultiErr("validator")
+	multi.Append(NewErrDefault(ErrCodeMissingField, "name is required", "validator"), dbErr)
+	mixed := NewMultiErr("api").Append(NewErrDefault(ErrCodeUnauthorized, "token expired", "api"), dbErr)
+
+	tests := []struct {
+		name     string
+		err      error
+		category Category
+		expected bool
+	}{
+		{name: "nil error", err: nil, category: CategoryDatabase, expected: false},
+		{name: "plain error", err: stderrors.New("boom"), category: CategoryGeneral, expected: false},
+		{name: "direct match", err: dbErr, category: CategoryDatabase, expected: true},
+		{name: "alias", err: dbErr, category: "db", expected: true},
+		{name: "other category", err: dbErr, category: CategoryHTTP, expected: false},
+		{name: "unknown category", err: dbErr, category: "shipping", expected: false},
+		{name: "wrapped with fmt", err: fmt.Errorf("lookup: %w", dbErr), category: CategoryDatabase, expected: true},
+		{
+			name:     "cause of a coded error",
+			err:      NewErr(ErrCodeExternal, dbErr, "sync failed", "sync"),
+			category: CategoryDatabase,
+			expected: true,
+		},
+		{name: "multi error member", err: multi, category: CategoryDatabase, expected: true},
+		{name: "multi error other member", err: multi, category: CategoryValidation, expected: true},
+		{
+			name:     "multi error aggregate code",
+			err:      mixed, // Aggregate code ErrCodeValidation, but no member is a validation error
+			category: CategoryValidation,
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, IsCategory(tt.err, tt.category))
+		})
+	}
+}
+
+// TestLookupCategory tests category details and defaults
+func TestLookupCategory(t *testing.T) {
+	info, ok := LookupCategory("authentication")
+	require.True(t, ok)
+	assert.Equal(t, CategoryAuth, info.Name)
+	assert.Equal(t, 1100, info.Min)
+	assert.Equal(t, 1199, info.Max)
+	assert.Equal(t, []string{"authentication"}, info.Aliases)
+	assert.Equal(t, CategoryDefaults{Severity: SeverityWarn, HTTPStatus: 401}, info.Defaults)
+
+	_, ok = LookupCategory("shipping")
+	assert.False(t, ok)
+
+	categories := GetCategories()
+	require.GreaterOrEqual(t, len(categories), len(builtinCategories))
+	for i := 1; i < len(categories); i++ {
+		assert.Less(t, categories[i-1].Max, categories[i].Min, "categories should be ordered by range")
+	}
+}
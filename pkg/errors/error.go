@@ -1,7 +1,10 @@
 package errors
 
 import (
-	"github.com/pkg/errors"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"runtime"
 )
 
 type Error interface {
@@ -17,17 +20,77 @@ type Err struct {
 	message string
 	er      error
 	app     string
+	details map[string]interface{}
+
+	retryable *bool    // Per-error override of the code's retry classification
+	severity  Severity // Per-error override of the code's severity, empty if unset
+	stack     stack    // Call stack where the error was created
 }
 
-//TODO: Need to integrate logger
+// TODO: Need to integrate logger
 func NewErr(code Code, err error, msg, app string) *Err {
-	return &Err{code: code, message: msg, er: err, app: app}
+	return &Err{code: code, message: msg, er: err, app: app, stack: callers(1)}
 }
 
 func NewErrDefault(code Code, msg, app string) *Err {
-	return NewErr(code,errors.New(msg), msg, app)
+	return &Err{code: code, message: msg, er: stderrors.New(msg), app: app, stack: callers(1)}
+}
+
+// WrapCode wraps err with a code and message; the message is prepended to the
+// error text as with fmt.Errorf("%s: %w", msg, err)
+// The app of the outermost Err in the chain is kept; a nil err returns a nil error,
+// so the result can be returned directly. The returned error is an *Err
+func WrapCode(err error, code Code, msg string) error {
+	if err == nil {
+		return nil
+	}
+	return wrap(err, code, msg)
+}
+
+// Wrapf wraps err with a formatted message while keeping the code of the outermost
+// coded error in the chain, ErrCodeUnknown for errors without one
+// The app of the outermost Err in the chain is kept; a nil err returns a nil error,
+// so the result can be returned directly. The returned error is an *Err
+func Wrapf(err error, format string, args ...interface{}) error {
+	if err == nil {
+		return nil
+	}
+	return wrap(err, CodeOf(err), fmt.Sprintf(format, args...))
+}
+
+// wrap builds the Err returned by WrapCode and Wrapf, recording the stack of their caller
+// If the code of the outermost Err is kept, its details and its severity and retryable
+// overrides are copied, so localized messages and classification survive added context
+func wrap(err error, code Code, msg string) *Err {
+	wrapped := &Err{
+		code:    code,
+		message: msg,
+		er:      &withMessage{msg: msg, err: err},
+		stack:   callers(2),
+	}
+	var inner *Err
+	if stderrors.As(err, &inner) {
+		wrapped.app = inner.app
+		if inner.code == code {
+			wrapped.details = inner.Details()
+			wrapped.retryable = inner.retryable
+			wrapped.severity = inner.severity
+		}
+	}
+	return wrapped
+}
+
+// withMessage prepends a message to an error like fmt.Errorf("%s: %w", msg, err)
+// Unlike fmt.Errorf it also links to the error through Cause, so Cause of a wrapped
+// error reaches the root cause the same way it did with github.com/pkg/errors
+type withMessage struct {
+	msg string
+	err error
 }
 
+func (w *withMessage) Error() string { return w.msg + ": " + w.err.Error() }
+func (w *withMessage) Cause() error  { return w.err }
+func (w *withMessage) Unwrap() error { return w.err }
 
 func (err *Err) Code() Code {
 	return err.code
@@ -37,16 +100,93 @@ func (err *Err) Message() string {
 	return err.message
 }
 
+// App returns the name of the application or component that created the error
+func (err *Err) App() string {
+	return err.app
+}
+
 func (err *Err) Er() error {
 	return err.er
 }
 
+// Details returns a copy of the key/value details attached to the error
+func (err *Err) Details() map[string]interface{} {
+	if len(err.details) == 0 {
+		return nil
+	}
+	details := make(map[string]interface{}, len(err.details))
+	for k, v := range err.details {
+		details[k] = v
+	}
+	return details
+}
+
+// WithDetail attaches a key/value detail to the error and returns it for chaining
+// Details are used as parameters when rendering localized messages
+func (err *Err) WithDetail(key string, value interface{}) *Err {
+	if err.details == nil {
+		err.details = make(map[string]interface{})
+	}
+	err.details[key] = value
+	return err
+}
+
+// WithRetryable overrides the retry classification of the code for this error
+// and returns the error for chaining
+func (err *Err) WithRetryable(retryable bool) *Err {
+	err.retryable = &retryable
+	return err
+}
+
+// WithSeverity overrides the severity of the code for this error and returns
+// the error for chaining, e.g. to log an expected ErrCodeDatabase at info level
+func (err *Err) WithSeverity(severity Severity) *Err {
+	err.severity = severity
+	return err
+}
+
+// Cause returns the root cause of the error by following Cause links, as
+// github.com/pkg/errors.Cause did; nil if there is no underlying error
+// Errors wrapped with fmt.Errorf("%w") have no Cause link and are returned as they are,
+// use Unwrap, errors.Is or errors.As to inspect the rest of their chain
 func (err *Err) Cause() error {
-	return errors.Cause(err.er)
+	return rootCause(err.er)
+}
+
+// rootCause follows Cause links until an error has none
+func rootCause(err error) error {
+	for err != nil {
+		cause, ok := err.(interface{ Cause() error })
+		if !ok {
+			return err
+		}
+		next := cause.Cause()
+		if next == nil {
+			return err
+		}
+		err = next
+	}
+	return nil
+}
+
+// StackTrace returns the call stack where the error was created, innermost frame first
+// Errors rebuilt from their wire form carry no stack
+func (err *Err) StackTrace() []runtime.Frame {
+	return err.stack.frames()
 }
 
+// Unwrap exposes the underlying error to the standard errors.Is and errors.As functions
+func (err *Err) Unwrap() error {
+	return err.er
+}
+
+// Wrap annotates the error with a message, keeping its code, app, details and overrides
+// Returns nil when there is no underlying error
 func (er *Err) Wrap(msg string) error {
-	return errors.Wrap(er.er, msg)
+	if er.er == nil {
+		return nil
+	}
+	return wrap(er, er.code, msg)
 }
 
 func (er *Err) Error() string {
@@ -54,4 +194,20 @@ func (er *Err) Error() string {
 		return ""
 	}
 	return er.er.Error()
-}
\ No newline at end of file
+}
+
+// Format implements fmt.Formatter: %s and %v print the error text,
+// %+v adds the stack where the error was created and %q quotes the text
+func (er *Err) Format(s fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		io.WriteString(s, er.Error())
+		if s.Flag('+') {
+			writeStack(s, er.StackTrace())
+		}
+	case 's':
+		io.WriteString(s, er.Error())
+	case 'q':
+		fmt.Fprintf(s, "%q", er.Error())
+	}
+}
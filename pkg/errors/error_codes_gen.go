@@ -0,0 +1,232 @@
+// Code generated by errgen from codes.yaml; DO NOT EDIT.
+
+package errors
+
+// Built-in error categories, each owning a range of codes
+const (
+	CategoryGeneral    Category = "general"    // General Errors (1000-1099)
+	CategoryAuth       Category = "auth"       // Authentication/Authorization Errors (1100-1199)
+	CategoryDatabase   Category = "database"   // Database Errors (1200-1299)
+	CategoryHTTP       Category = "http"       // HTTP/Network Errors (1300-1399)
+	CategoryValidation Category = "validation" // Validation Errors (1400-1499)
+	CategoryExternal   Category = "external"   // External Service Errors (1500-1599)
+	CategoryBusiness   Category = "business"   // Business Logic Errors (1600-1699)
+	CategoryResource   Category = "resource"   // Resource Errors (1700-1799)
+	CategoryConfig     Category = "config"     // Configuration Errors (1800-1899)
+)
+
+// Standardized error codes organized by category for consistent error handling
+// Each category uses a specific number range to avoid conflicts and enable filtering
+const (
+	// General Errors (1000-1099) - System-level and unclassified errors
+	ErrCodeUnknown        Code = "1000" // Unknown or unexpected error - fallback for unhandled cases
+	ErrCodeInternal       Code = "1001" // Internal server error - unexpected system failures
+	ErrCodeConfiguration  Code = "1002" // Configuration error - invalid or missing configuration
+	ErrCodeInitialization Code = "1003" // Initialization error - startup and setup failures
+
+	// Authentication/Authorization Errors (1100-1199) - Security and access control
+	ErrCodeAuth         Code = "1100" // General authentication error - catch-all for auth issues
+	ErrCodeUnauthorized Code = "1101" // Unauthorized access - missing or invalid credentials
+	ErrCodeTokenInvalid Code = "1102" // Invalid token - malformed or corrupted tokens
+	ErrCodeTokenExpired Code = "1103" // Expired token - valid but time-expired tokens
+	ErrCodePermission   Code = "1104" // Permission denied - insufficient privileges
+
+	// Database Errors (1200-1299) - Data persistence and retrieval issues
+	ErrCodeDatabase     Code = "1200" // General database error - unspecified DB issues
+	ErrCodeDBConnection Code = "1201" // Database connection error - connectivity problems
+	ErrCodeDBQuery      Code = "1202" // Database query error - SQL syntax or execution issues
+	ErrCodeDBDuplicate  Code = "1203" // Duplicate entry - unique constraint violations
+	ErrCodeDBNotFound   Code = "1204" // Record not found - query returned no results
+	ErrCodeDBValidation Code = "1205" // Database validation error - constraint violations
+
+	// HTTP/Network Errors (1300-1399) - Communication and protocol issues
+	ErrCodeHTTP         Code = "1300" // General HTTP error - unspecified HTTP issues
+	ErrCodeHTTPRequest  Code = "1301" // Invalid HTTP request - malformed requests
+	ErrCodeHTTPResponse Code = "1302" // Invalid HTTP response - unexpected response format
+	ErrCodeNetwork      Code = "1303" // Network error - connectivity and routing issues
+	ErrCodeTimeout      Code = "1304" // Request timeout - operations exceeding time limits
+
+	// Validation Errors (1400-1499) - Input validation and data format issues
+	ErrCodeValidation    Code = "1400" // General validation error - unspecified validation failures
+	ErrCodeInvalidInput  Code = "1401" // Invalid input - malformed or incorrect input data
+	ErrCodeInvalidFormat Code = "1402" // Invalid format - wrong data format or structure
+	ErrCodeMissingField  Code = "1403" // Missing required field - incomplete data submissions
+	ErrCodeInvalidState  Code = "1404" // Invalid state - operations in wrong system state
+
+	// External Service Errors (1500-1599) - Third-party integration issues
+	ErrCodeExternal    Code = "1500" // General external service error - unspecified external issues
+	ErrCodeAPIError    Code = "1501" // External API error - third-party API failures
+	ErrCodeThirdParty  Code = "1502" // Third-party service error - external service unavailable
+	ErrCodeIntegration Code = "1503" // Integration error - integration setup or configuration issues
+
+	// Business Logic Errors (1600-1699) - Application-specific logic violations
+	ErrCodeBusiness  Code = "1600" // General business logic error - unspecified business rule violations
+	ErrCodeWorkflow  Code = "1601" // Workflow error - process or state machine violations
+	ErrCodeOperation Code = "1602" // Operation error - invalid operations or sequences
+	ErrCodeLimit     Code = "1603" // Limit exceeded - rate limits, quotas, or capacity exceeded
+
+	// Resource Errors (1700-1799) - Resource management and availability issues
+	ErrCodeResource  Code = "1700" // General resource error - unspecified resource issues
+	ErrCodeNotFound  Code = "1701" // Resource not found - requested resource doesn't exist
+	ErrCodeConflict  Code = "1702" // Resource conflict - concurrent modification conflicts
+	ErrCodeLocked    Code = "1703" // Resource locked - resource temporarily unavailable
+	ErrCodeExhausted Code = "1704" // Resource exhausted - insufficient resources available
+
+	// Configuration Errors (1800-1899) - Configuration and environment issues
+	ErrCodeConfig            Code = "1800" // General configuration error - unspecified config issues
+	ErrCodeConfigMissing     Code = "1801" // Missing configuration - required config parameters absent
+	ErrCodeConfigInvalid     Code = "1802" // Invalid configuration value - config values out of range or format
+	ErrCodeConfigType        Code = "1803" // Configuration type mismatch - wrong data type for config
+	ErrCodeConfigFile        Code = "1804" // Configuration file error - file reading or parsing issues
+	ErrCodeConfigEnvironment Code = "1805" // Environment configuration error - environment variable issues
+	ErrCodeConfigOverride    Code = "1806" // Configuration override error - conflicting config sources
+	ErrCodeConfigDependency  Code = "1807" // Configuration dependency error - missing dependent configs
+)
+
+// CodeDetails maps the built-in error codes to human-readable descriptions
+// It seeds the code registry at startup; application codes are added with Register
+var CodeDetails = map[Code]string{
+	// General Errors
+	ErrCodeUnknown:        "Unknown or unexpected error occurred",
+	ErrCodeInternal:       "Internal server error - please contact support",
+	ErrCodeConfiguration:  "System configuration error detected",
+	ErrCodeInitialization: "Application initialization failed",
+
+	// Authentication/Authorization Errors
+	ErrCodeAuth:         "Authentication failed - please check credentials",
+	ErrCodeUnauthorized: "Access denied - authentication required",
+	ErrCodeTokenInvalid: "Invalid authentication token provided",
+	ErrCodeTokenExpired: "Authentication token has expired",
+	ErrCodePermission:   "Insufficient permissions for this operation",
+
+	// Database Errors
+	ErrCodeDatabase:     "Database operation failed",
+	ErrCodeDBConnection: "Unable to connect to database",
+	ErrCodeDBQuery:      "Database query execution failed",
+	ErrCodeDBDuplicate:  "Duplicate entry - record already exists",
+	ErrCodeDBNotFound:   "Requested record not found in database",
+	ErrCodeDBValidation: "Database validation constraint violated",
+
+	// HTTP/Network Errors
+	ErrCodeHTTP:         "HTTP request processing failed",
+	ErrCodeHTTPRequest:  "Malformed or invalid HTTP request",
+	ErrCodeHTTPResponse: "Invalid or unexpected HTTP response",
+	ErrCodeNetwork:      "Network connectivity issue detected",
+	ErrCodeTimeout:      "Operation timed out - please try again",
+
+	// Validation Errors
+	ErrCodeValidation:    "Input validation failed",
+	ErrCodeInvalidInput:  "Invalid input data provided",
+	ErrCodeInvalidFormat: "Data format is incorrect or unsupported",
+	ErrCodeMissingField:  "Required field is missing or empty",
+	ErrCodeInvalidState:  "Operation not allowed in current state",
+
+	// External Service Errors
+	ErrCodeExternal:    "External service operation failed",
+	ErrCodeAPIError:    "Third-party API returned an error",
+	ErrCodeThirdParty:  "Third-party service is unavailable",
+	ErrCodeIntegration: "Service integration configuration error",
+
+	// Business Logic Errors
+	ErrCodeBusiness:  "Business rule validation failed",
+	ErrCodeWorkflow:  "Workflow process violation detected",
+	ErrCodeOperation: "Invalid operation or sequence attempted",
+	ErrCodeLimit:     "Rate limit or quota exceeded",
+
+	// Resource Errors
+	ErrCodeResource:  "Resource operation failed",
+	ErrCodeNotFound:  "Requested resource could not be found",
+	ErrCodeConflict:  "Resource conflict - concurrent modification detected",
+	ErrCodeLocked:    "Resource is temporarily locked or unavailable",
+	ErrCodeExhausted: "Insufficient resources available",
+
+	// Configuration Errors
+	ErrCodeConfig:            "Configuration error detected",
+	ErrCodeConfigMissing:     "Required configuration parameter is missing",
+	ErrCodeConfigInvalid:     "Configuration value is invalid or out of range",
+	ErrCodeConfigType:        "Configuration parameter has wrong data type",
+	ErrCodeConfigFile:        "Configuration file could not be read or parsed",
+	ErrCodeConfigEnvironment: "Environment configuration variable error",
+	ErrCodeConfigOverride:    "Conflicting configuration sources detected",
+	ErrCodeConfigDependency:  "Missing configuration dependency",
+}
+
+// builtinCategories lists the categories shipped with the library and the ranges they own
+var builtinCategories = []builtinCategory{
+	{name: CategoryGeneral, min: 1000, max: 1099, defaults: CategoryDefaults{Severity: SeverityError, HTTPStatus: 500}},
+	{name: CategoryAuth, min: 1100, max: 1199, aliases: []string{"authentication"}, defaults: CategoryDefaults{Severity: SeverityWarn, HTTPStatus: 401}},
+	{name: CategoryDatabase, min: 1200, max: 1299, aliases: []string{"db"}, defaults: CategoryDefaults{Severity: SeverityError, HTTPStatus: 500}},
+	{name: CategoryHTTP, min: 1300, max: 1399, aliases: []string{"network"}, defaults: CategoryDefaults{Severity: SeverityError, HTTPStatus: 500}},
+	{name: CategoryValidation, min: 1400, max: 1499, defaults: CategoryDefaults{Severity: SeverityWarn, HTTPStatus: 400}},
+	{name: CategoryExternal, min: 1500, max: 1599, defaults: CategoryDefaults{Severity: SeverityError, HTTPStatus: 500}},
+	{name: CategoryBusiness, min: 1600, max: 1699, defaults: CategoryDefaults{Severity: SeverityWarn, HTTPStatus: 422}},
+	{name: CategoryResource, min: 1700, max: 1799, defaults: CategoryDefaults{Severity: SeverityError, HTTPStatus: 500}},
+	{name: CategoryConfig, min: 1800, max: 1899, aliases: []string{"configuration"}, defaults: CategoryDefaults{Severity: SeverityError, HTTPStatus: 500}},
+}
+
+// builtinCodeOptions holds the registration options of the built-in codes
+var builtinCodeOptions = map[Code]CodeOptions{
+	// General Errors
+	ErrCodeUnknown:        {Name: "ErrCodeUnknown", HTTPStatus: 500},
+	ErrCodeInternal:       {Name: "ErrCodeInternal", HTTPStatus: 500},
+	ErrCodeConfiguration:  {Name: "ErrCodeConfiguration", HTTPStatus: 500},
+	ErrCodeInitialization: {Name: "ErrCodeInitialization", HTTPStatus: 500, Severity: SeverityCritical},
+
+	// Authentication/Authorization Errors
+	ErrCodeAuth:         {Name: "ErrCodeAuth", HTTPStatus: 401},
+	ErrCodeUnauthorized: {Name: "ErrCodeUnauthorized", HTTPStatus: 401},
+	ErrCodeTokenInvalid: {Name: "ErrCodeTokenInvalid", HTTPStatus: 401},
+	ErrCodeTokenExpired: {Name: "ErrCodeTokenExpired", HTTPStatus: 401},
+	ErrCodePermission:   {Name: "ErrCodePermission", HTTPStatus: 403},
+
+	// Database Errors
+	ErrCodeDatabase:     {Name: "ErrCodeDatabase", HTTPStatus: 500},
+	ErrCodeDBConnection: {Name: "ErrCodeDBConnection", HTTPStatus: 500, Retryable: Bool(true)},
+	ErrCodeDBQuery:      {Name: "ErrCodeDBQuery", HTTPStatus: 500},
+	ErrCodeDBDuplicate:  {Name: "ErrCodeDBDuplicate", HTTPStatus: 409},
+	ErrCodeDBNotFound:   {Name: "ErrCodeDBNotFound", HTTPStatus: 404, Severity: SeverityInfo},
+	ErrCodeDBValidation: {Name: "ErrCodeDBValidation", HTTPStatus: 400},
+
+	// HTTP/Network Errors
+	ErrCodeHTTP:         {Name: "ErrCodeHTTP", HTTPStatus: 500},
+	ErrCodeHTTPRequest:  {Name: "ErrCodeHTTPRequest", HTTPStatus: 400},
+	ErrCodeHTTPResponse: {Name: "ErrCodeHTTPResponse", HTTPStatus: 500},
+	ErrCodeNetwork:      {Name: "ErrCodeNetwork", HTTPStatus: 500, Retryable: Bool(true)},
+	ErrCodeTimeout:      {Name: "ErrCodeTimeout", HTTPStatus: 504, Retryable: Bool(true)},
+
+	// Validation Errors
+	ErrCodeValidation:    {Name: "ErrCodeValidation", HTTPStatus: 400},
+	ErrCodeInvalidInput:  {Name: "ErrCodeInvalidInput", HTTPStatus: 400},
+	ErrCodeInvalidFormat: {Name: "ErrCodeInvalidFormat", HTTPStatus: 400},
+	ErrCodeMissingField:  {Name: "ErrCodeMissingField", HTTPStatus: 400},
+	ErrCodeInvalidState:  {Name: "ErrCodeInvalidState", HTTPStatus: 409},
+
+	// External Service Errors
+	ErrCodeExternal:    {Name: "ErrCodeExternal", HTTPStatus: 500},
+	ErrCodeAPIError:    {Name: "ErrCodeAPIError", HTTPStatus: 502},
+	ErrCodeThirdParty:  {Name: "ErrCodeThirdParty", HTTPStatus: 503, Retryable: Bool(true)},
+	ErrCodeIntegration: {Name: "ErrCodeIntegration", HTTPStatus: 500},
+
+	// Business Logic Errors
+	ErrCodeBusiness:  {Name: "ErrCodeBusiness", HTTPStatus: 422},
+	ErrCodeWorkflow:  {Name: "ErrCodeWorkflow", HTTPStatus: 422},
+	ErrCodeOperation: {Name: "ErrCodeOperation", HTTPStatus: 422},
+	ErrCodeLimit:     {Name: "ErrCodeLimit", HTTPStatus: 429},
+
+	// Resource Errors
+	ErrCodeResource:  {Name: "ErrCodeResource", HTTPStatus: 500},
+	ErrCodeNotFound:  {Name: "ErrCodeNotFound", HTTPStatus: 404, Severity: SeverityInfo},
+	ErrCodeConflict:  {Name: "ErrCodeConflict", HTTPStatus: 409},
+	ErrCodeLocked:    {Name: "ErrCodeLocked", HTTPStatus: 423, Retryable: Bool(true)},
+	ErrCodeExhausted: {Name: "ErrCodeExhausted", HTTPStatus: 503},
+
+	// Configuration Errors
+	ErrCodeConfig:            {Name: "ErrCodeConfig", HTTPStatus: 500},
+	ErrCodeConfigMissing:     {Name: "ErrCodeConfigMissing", HTTPStatus: 500},
+	ErrCodeConfigInvalid:     {Name: "ErrCodeConfigInvalid", HTTPStatus: 500},
+	ErrCodeConfigType:        {Name: "ErrCodeConfigType", HTTPStatus: 500},
+	ErrCodeConfigFile:        {Name: "ErrCodeConfigFile", HTTPStatus: 500},
+	ErrCodeConfigEnvironment: {Name: "ErrCodeConfigEnvironment", HTTPStatus: 500},
+	ErrCodeConfigOverride:    {Name: "ErrCodeConfigOverride", HTTPStatus: 500},
+	ErrCodeConfigDependency:  {Name: "ErrCodeConfigDependency", HTTPStatus: 500},
+}
@@ -0,0 +1,90 @@
+// Code generated by errgen from codes.yaml; DO NOT EDIT.
+
+package errors
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestGeneratedCodeCatalog tests that every catalog code is registered in its category range
+func TestGeneratedCodeCatalog(t *testing.T) {
+	tests := []struct {
+		name       string
+		code       Code
+		category   Category
+		minRange   int
+		maxRange   int
+		httpStatus int
+		retryable  bool
+		severity   Severity
+	}{
+		{"ErrCodeUnknown", ErrCodeUnknown, CategoryGeneral, 1000, 1099, 500, false, SeverityError},
+		{"ErrCodeInternal", ErrCodeInternal, CategoryGeneral, 1000, 1099, 500, false, SeverityError},
+		{"ErrCodeConfiguration", ErrCodeConfiguration, CategoryGeneral, 1000, 1099, 500, false, SeverityError},
+		{"ErrCodeInitialization", ErrCodeInitialization, CategoryGeneral, 1000, 1099, 500, false, SeverityCritical},
+		{"ErrCodeAuth", ErrCodeAuth, CategoryAuth, 1100, 1199, 401, false, SeverityWarn},
+		{"ErrCodeUnauthorized", ErrCodeUnauthorized, CategoryAuth, 1100, 1199, 401, false, SeverityWarn},
+		{"ErrCodeTokenInvalid", ErrCodeTokenInvalid, CategoryAuth, 1100, 1199, 401, false, SeverityWarn},
+		{"ErrCodeTokenExpired", ErrCodeTokenExpired, CategoryAuth, 1100, 1199, 401, false, SeverityWarn},
+		{"ErrCodePermission", ErrCodePermission, CategoryAuth, 1100, 1199, 403, false, SeverityWarn},
+		{"ErrCodeDatabase", ErrCodeDatabase, CategoryDatabase, 1200, 1299, 500, false, SeverityError},
+		{"ErrCodeDBConnection", ErrCodeDBConnection, CategoryDatabase, 1200, 1299, 500, true, SeverityError},
+		{"ErrCodeDBQuery", ErrCodeDBQuery, CategoryDatabase, 1200, 1299, 500, false, SeverityError},
+		{"ErrCodeDBDuplicate", ErrCodeDBDuplicate, CategoryDatabase, 1200, 1299, 409, false, SeverityError},
+		{"ErrCodeDBNotFound", ErrCodeDBNotFound, CategoryDatabase, 1200, 1299, 404, false, SeverityInfo},
+		{"ErrCodeDBValidation", ErrCodeDBValidation, CategoryDatabase, 1200, 1299, 400, false, SeverityError},
+		{"ErrCodeHTTP", ErrCodeHTTP, CategoryHTTP, 1300, 1399, 500, false, SeverityError},
+		{"ErrCodeHTTPRequest", ErrCodeHTTPRequest, CategoryHTTP, 1300, 1399, 400, false, SeverityError},
+		{"ErrCodeHTTPResponse", ErrCodeHTTPResponse, CategoryHTTP, 1300, 1399, 500, false, SeverityError},
+		{"ErrCodeNetwork", ErrCodeNetwork, CategoryHTTP, 1300, 1399, 500, true, SeverityError},
+		{"ErrCodeTimeout", ErrCodeTimeout, CategoryHTTP, 1300, 1399, 504, true, SeverityError},
+		{"ErrCodeValidation", ErrCodeValidation, CategoryValidation, 1400, 1499, 400, false, SeverityWarn},
+		{"ErrCodeInvalidInput", ErrCodeInvalidInput, CategoryValidation, 1400, 1499, 400, false, SeverityWarn},
+		{"ErrCodeInvalidFormat", ErrCodeInvalidFormat, CategoryValidation, 1400, 1499, 400, false, SeverityWarn},
+		{"ErrCodeMissingField", ErrCodeMissingField, CategoryValidation, 1400, 1499, 400, false, SeverityWarn},
+		{"ErrCodeInvalidState", ErrCodeInvalidState, CategoryValidation, 1400, 1499, 409, false, SeverityWarn},
+		{"ErrCodeExternal", ErrCodeExternal, CategoryExternal, 1500, 1599, 500, false, SeverityError},
+		{"ErrCodeAPIError", ErrCodeAPIError, CategoryExternal, 1500, 1599, 502, false, SeverityError},
+		{"ErrCodeThirdParty", ErrCodeThirdParty, CategoryExternal, 1500, 1599, 503, true, SeverityError},
+		{"ErrCodeIntegration", ErrCodeIntegration, CategoryExternal, 1500, 1599, 500, false, SeverityError},
+		{"ErrCodeBusiness", ErrCodeBusiness, CategoryBusiness, 1600, 1699, 422, false, SeverityWarn},
+		{"ErrCodeWorkflow", ErrCodeWorkflow, CategoryBusiness, 1600, 1699, 422, false, SeverityWarn},
+		{"ErrCodeOperation", ErrCodeOperation, CategoryBusiness, 1600, 1699, 422, false, SeverityWarn},
+		{"ErrCodeLimit", ErrCodeLimit, CategoryBusiness, 1600, 1699, 429, false, SeverityWarn},
+		{"ErrCodeResource", ErrCodeResource, CategoryResource, 1700, 1799, 500, false, SeverityError},
+		{"ErrCodeNotFound", ErrCodeNotFound, CategoryResource, 1700, 1799, 404, false, SeverityInfo},
+		{"ErrCodeConflict", ErrCodeConflict, CategoryResource, 1700, 1799, 409, false, SeverityError},
+		{"ErrCodeLocked", ErrCodeLocked, CategoryResource, 1700, 1799, 423, true, SeverityError},
+		{"ErrCodeExhausted", ErrCodeExhausted, CategoryResource, 1700, 1799, 503, false, SeverityError},
+		{"ErrCodeConfig", ErrCodeConfig, CategoryConfig, 1800, 1899, 500, false, SeverityError},
+		{"ErrCodeConfigMissing", ErrCodeConfigMissing, CategoryConfig, 1800, 1899, 500, false, SeverityError},
+		{"ErrCodeConfigInvalid", ErrCodeConfigInvalid, CategoryConfig, 1800, 1899, 500, false, SeverityError},
+		{"ErrCodeConfigType", ErrCodeConfigType, CategoryConfig, 1800, 1899, 500, false, SeverityError},
+		{"ErrCodeConfigFile", ErrCodeConfigFile, CategoryConfig, 1800, 1899, 500, false, SeverityError},
+		{"ErrCodeConfigEnvironment", ErrCodeConfigEnvironment, CategoryConfig, 1800, 1899, 500, false, SeverityError},
+		{"ErrCodeConfigOverride", ErrCodeConfigOverride, CategoryConfig, 1800, 1899, 500, false, SeverityError},
+		{"ErrCodeConfigDependency", ErrCodeConfigDependency, CategoryConfig, 1800, 1899, 500, false, SeverityError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info, ok := LookupCode(tt.code)
+			require.True(t, ok, "Code %s should be registered", tt.code)
+			assert.Equal(t, tt.category, info.Category)
+			assert.Equal(t, tt.category, tt.code.Category())
+			assert.Equal(t, tt.name, info.Name)
+			assert.Equal(t, tt.httpStatus, info.HTTPStatus)
+			assert.Equal(t, tt.retryable, info.Retryable)
+			assert.Equal(t, tt.severity, info.Severity)
+			assert.Contains(t, GetCodesByCategory(string(tt.category)), tt.code)
+
+			codeNum, ok := parseCodeNumber(tt.code)
+			require.True(t, ok, "Code should be numeric: %s", tt.code)
+			assert.GreaterOrEqual(t, codeNum, tt.minRange, "Code %s should be >= %d", tt.code, tt.minRange)
+			assert.LessOrEqual(t, codeNum, tt.maxRange, "Code %s should be <= %d", tt.code, tt.maxRange)
+		})
+	}
+}
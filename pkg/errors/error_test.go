@@ -1,9 +1,13 @@
 package errors
 
 import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"syscall"
 	"testing"
 
-	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -287,11 +291,31 @@ func TestErr_Cause(t *testing.T) {
 			err: &Err{
 				code:    ErrCodeExternal,
 				message: "External service error",
-				er:      errors.Wrap(errors.New("network timeout"), "API call failed"),
+				er:      WrapCode(errors.New("network timeout"), ErrCodeNetwork, "API call failed"),
 				app:     "api-client",
 			},
 			expectedCause: errors.New("network timeout"),
 		},
+		{
+			name: "cause stops at a %w chain",
+			err: &Err{
+				code:    ErrCodeExternal,
+				message: "External service error",
+				er:      fmt.Errorf("API call failed: %w", errors.New("network timeout")),
+				app:     "api-client",
+			},
+			expectedCause: fmt.Errorf("API call failed: %w", errors.New("network timeout")),
+		},
+		{
+			name: "cause keeps a path error",
+			err: &Err{
+				code:    ErrCodeConfigFile,
+				message: "Config error",
+				er:      &os.PathError{Op: "open", Path: "/etc/app.yaml", Err: syscall.ENOENT},
+				app:     "config",
+			},
+			expectedCause: &os.PathError{Op: "open", Path: "/etc/app.yaml", Err: syscall.ENOENT},
+		},
 		{
 			name: "get cause of nil error",
 			err: &Err{
@@ -310,6 +334,7 @@ func TestErr_Cause(t *testing.T) {
 			
 			if tt.expectedCause != nil {
 				require.NotNil(t, result)
+				assert.IsType(t, tt.expectedCause, result)
 				assert.Equal(t, tt.expectedCause.Error(), result.Error())
 			} else {
 				assert.Nil(t, result)
@@ -406,8 +431,8 @@ func TestErr_ChainedErrors(t *testing.T) {
 	t.Run("handle chained errors correctly", func(t *testing.T) {
 		// Create a chain of errors
 		originalErr := errors.New("original error")
-		wrappedErr := errors.Wrap(originalErr, "first wrap")
-		doubleWrappedErr := errors.Wrap(wrappedErr, "second wrap")
+		wrappedErr := Wrapf(originalErr, "first wrap")
+		doubleWrappedErr := Wrapf(wrappedErr, "second wrap")
 		
 		err := NewErr(ErrCodeExternal, doubleWrappedErr, "Custom error message", "testapp")
 		
@@ -421,4 +446,138 @@ func TestErr_ChainedErrors(t *testing.T) {
 		assert.Contains(t, err.Error(), "first wrap")
 		assert.Contains(t, err.Error(), "original error")
 	})
-} 
\ No newline at end of file
+}
+
+// TestErr_Details tests attaching details and that Details returns a copy
+func TestErr_Details(t *testing.T) {
+	t.Run("attach and copy details", func(t *testing.T) {
+		err := NewErrDefault(ErrCodeDBNotFound, "user not found", "testapp")
+		assert.Nil(t, err.Details())
+
+		result := err.WithDetail("id", 42).WithDetail("table", "users")
+		assert.Same(t, err, result)
+		assert.Equal(t, map[string]interface{}{"id": 42, "table": "users"}, err.Details())
+
+		// Mutating the returned map must not change the error
+		details := err.Details()
+		details["id"] = 7
+		assert.Equal(t, 42, err.Details()["id"])
+	})
+}
+
+// asErr returns err as an *Err, failing the test if it is none
+func asErr(t *testing.T, err error) *Err {
+	t.Helper()
+	coded, ok := err.(*Err)
+	require.True(t, ok, "expected an *Err, got %T", err)
+	return coded
+}
+
+// TestWrapCode tests wrapping errors with a new code
+func TestWrapCode(t *testing.T) {
+	assert.Nil(t, WrapCode(nil, ErrCodeDatabase, "load failed"))
+	var wrappedNil error = WrapCode(nil, ErrCodeDatabase, "load failed")
+	assert.True(t, wrappedNil == nil, "a nil err must not become a non-nil error interface")
+
+	root := errors.New("connection reset")
+	inner := NewErr(ErrCodeNetwork, root, "network failure", "db-client")
+	err := asErr(t, WrapCode(inner, ErrCodeDatabase, "load user"))
+
+	assert.Equal(t, ErrCodeDatabase, err.Code())
+	assert.Equal(t, "load user", err.Message())
+	assert.Equal(t, "db-client", err.App(), "app is inherited from the wrapped Err")
+	assert.Equal(t, "load user: connection reset", err.Error())
+	assert.True(t, errors.Is(err, root))
+	assert.Same(t, root, err.Cause())
+
+	var coded *Err
+	require.True(t, errors.As(err.Unwrap(), &coded))
+	assert.Equal(t, ErrCodeNetwork, coded.Code())
+}
+
+// TestWrapf tests that Wrapf keeps the code of the wrapped error
+func TestWrapf(t *testing.T) {
+	assert.Nil(t, Wrapf(nil, "user %d", 42))
+	var wrappedNil error = Wrapf(nil, "user %d", 42)
+	assert.True(t, wrappedNil == nil, "a nil err must not become a non-nil error interface")
+
+	inner := NewErrDefault(ErrCodeDBNotFound, "no rows", "users")
+	err := asErr(t, Wrapf(fmt.Errorf("query: %w", inner), "load user %d", 42))
+	assert.Equal(t, ErrCodeDBNotFound, err.Code())
+	assert.Equal(t, "load user 42", err.Message())
+	assert.Equal(t, "users", err.App())
+	assert.Equal(t, "load user 42: query: no rows", err.Error())
+
+	twice := asErr(t, Wrapf(err, "handler"))
+	assert.Equal(t, ErrCodeDBNotFound, twice.Code(), "the code survives repeated wrapping")
+	assert.Equal(t, "query: no rows", twice.Cause().Error(), "Cause stops at the fmt.Errorf link")
+
+	// Details and overrides of the wrapped error are kept along with its code
+	classified := NewErrDefault(ErrCodeDBNotFound, "no rows", "users").
+		WithDetail("id", 42).
+		WithRetryable(true).
+		WithSeverity(SeverityInfo)
+	kept := asErr(t, Wrapf(classified, "load user"))
+	assert.Equal(t, map[string]interface{}{"id": 42}, kept.Details())
+	assert.True(t, IsRetryable(kept))
+	assert.Equal(t, SeverityInfo, SeverityOf(kept))
+	kept.WithDetail("id", 7)
+	assert.Equal(t, 42, classified.Details()["id"], "the details are copied, not shared")
+
+	// A new code starts without them
+	recoded := asErr(t, WrapCode(classified, ErrCodeInternal, "load user"))
+	assert.Nil(t, recoded.Details())
+	assert.Equal(t, SeverityOf(NewErrDefault(ErrCodeInternal, "internal", "")), SeverityOf(recoded))
+
+	plain := asErr(t, Wrapf(errors.New("boom"), "startup"))
+	assert.Equal(t, ErrCodeUnknown, plain.Code())
+	assert.Empty(t, plain.App())
+}
+
+// TestErr_WrapKeepsCode tests that the Wrap method keeps the code and the error itself in the chain
+func TestErr_WrapKeepsCode(t *testing.T) {
+	err := NewErrDefault(ErrCodeTimeout, "timed out", "client")
+	wrapped := err.Wrap("call payments")
+
+	assert.Equal(t, ErrCodeTimeout, CodeOf(wrapped))
+	assert.True(t, errors.Is(wrapped, err))
+	assert.Nil(t, NewErr(ErrCodeInternal, nil, "no cause", "app").Wrap("ignored"))
+}
+
+// TestErr_StackTrace tests that errors record where they were created
+func TestErr_StackTrace(t *testing.T) {
+	tests := []struct {
+		name string
+		err  *Err
+	}{
+		{name: "NewErr", err: NewErr(ErrCodeInternal, errors.New("boom"), "boom", "app")},
+		{name: "NewErrDefault", err: NewErrDefault(ErrCodeInternal, "boom", "app")},
+		{name: "WrapCode", err: WrapCode(errors.New("boom"), ErrCodeInternal, "wrapped").(*Err)},
+		{name: "Wrapf", err: Wrapf(errors.New("boom"), "wrapped").(*Err)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			frames := tt.err.StackTrace()
+			require.NotEmpty(t, frames)
+			assert.Equal(t, "TestErr_StackTrace", funcName(frames[0].Function),
+				"the first frame should be the caller of the constructor")
+		})
+	}
+
+	assert.Empty(t, FromWire(ToWire(NewErrDefault(ErrCodeInternal, "boom", "app"))).StackTrace())
+}
+
+// TestErr_Format tests the fmt verbs supported by Err
+func TestErr_Format(t *testing.T) {
+	err := NewErrDefault(ErrCodeInternal, "boom", "app")
+
+	assert.Equal(t, "boom", fmt.Sprintf("%s", err))
+	assert.Equal(t, "boom", fmt.Sprintf("%v", err))
+	assert.Equal(t, `"boom"`, fmt.Sprintf("%q", err))
+
+	verbose := fmt.Sprintf("%+v", err)
+	assert.True(t, strings.HasPrefix(verbose, "boom\n"))
+	assert.Contains(t, verbose, "TestErr_Format")
+	assert.Contains(t, verbose, "error_test.go:")
+}
@@ -0,0 +1,88 @@
+package errors
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	stderrors "errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// fingerprintFrames is the number of top stack frames that contribute to a fingerprint
+const fingerprintFrames = 4
+
+// Patterns replacing variable parts of messages, applied in order
+var messageNormalizers = []struct {
+	pattern     *regexp.Regexp
+	replacement string
+}{
+	{regexp.MustCompile(`"[^"]*"|'[^']*'`), "<str>"},
+	{regexp.MustCompile(`(?i)\b[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}\b`), "<uuid>"},
+	{regexp.MustCompile(`(?i)\b0x[0-9a-f]+\b`), "<hex>"},
+	{regexp.MustCompile(`\d+(\.\d+)?`), "<num>"},
+}
+
+// NormalizeMessage turns a message into a template by replacing quoted strings,
+// UUIDs, hex values and numbers with placeholders
+// "user 42 not found" and "user 7 not found" both become "user <num> not found"
+func NormalizeMessage(msg string) string {
+	for _, n := range messageNormalizers {
+		msg = n.pattern.ReplaceAllString(msg, n.replacement)
+	}
+	return strings.TrimSpace(msg)
+}
+
+// Fingerprint derives a stable hash for an error from its code, its normalized
+// message template and the top frames of the stack where it was created
+// Errors that differ only in ids, counters or quoted values share a fingerprint
+func Fingerprint(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	code, template := fingerprintParts(err)
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\n%s\n", code, template)
+	for _, frame := range topFrames(err, fingerprintFrames) {
+		fmt.Fprintf(h, "%s\n", frame)
+	}
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
+
+// fingerprintParts returns the code and message template that identify an error
+func fingerprintParts(err error) (Code, string) {
+	var coded Error
+	if stderrors.As(err, &coded) {
+		msg := coded.Message()
+		if msg == "" {
+			msg = coded.Error()
+		}
+		return coded.Code(), NormalizeMessage(msg)
+	}
+	return ErrCodeUnknown, NormalizeMessage(err.Error())
+}
+
+// topFrames returns the function names of the innermost recorded stack in the chain
+// Line numbers are left out so fingerprints survive unrelated edits to the same file
+func topFrames(err error, n int) []string {
+	var tracer stackTracer
+	for e := err; e != nil; e = stderrors.Unwrap(e) {
+		if st, ok := e.(stackTracer); ok {
+			tracer = st
+		}
+	}
+	if tracer == nil {
+		return nil
+	}
+
+	stack := tracer.StackTrace()
+	if len(stack) > n {
+		stack = stack[:n]
+	}
+	frames := make([]string, len(stack))
+	for i, frame := range stack {
+		frames[i] = funcName(frame.Function)
+	}
+	return frames
+}
@@ -0,0 +1,52 @@
+package errors
+
+import (
+	stderrors "errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newLookupErr creates errors from a single call site so their stacks match
+func newLookupErr(id int) *Err {
+	return NewErrDefault(ErrCodeDBNotFound, fmt.Sprintf("user %d not found", id), "app")
+}
+
+// TestNormalizeMessage tests that variable parts of messages are replaced
+func TestNormalizeMessage(t *testing.T) {
+	tests := []struct {
+		name     string
+		msg      string
+		expected string
+	}{
+		{name: "numbers", msg: "user 42 not found after 1.5s", expected: "user <num> not found after <num>s"},
+		{name: "quoted strings", msg: `table "users" and 'orders' missing`, expected: "table <str> and <str> missing"},
+		{name: "uuid", msg: "order 3f2b8c1e-9a4d-4e2f-8b6a-1c2d3e4f5a6b failed", expected: "order <uuid> failed"},
+		{name: "hex", msg: "bad pointer 0xDEADBEEF", expected: "bad pointer <hex>"},
+		{name: "no variables", msg: "connection refused", expected: "connection refused"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, NormalizeMessage(tt.msg))
+		})
+	}
+}
+
+// TestFingerprint tests fingerprint stability and separation
+func TestFingerprint(t *testing.T) {
+	first := Fingerprint(newLookupErr(1))
+	assert.Len(t, first, 16)
+	assert.Equal(t, first, Fingerprint(newLookupErr(2)), "errors differing only in ids should match")
+	assert.Equal(t, first, Fingerprint(fmt.Errorf("wrapped: %w", newLookupErr(3))), "wrapping should not change the fingerprint")
+
+	otherCode := NewErrDefault(ErrCodeNotFound, "user 1 not found", "app")
+	assert.NotEqual(t, first, Fingerprint(otherCode), "different codes should differ")
+
+	otherMessage := NewErrDefault(ErrCodeDBNotFound, "order 1 not found", "app")
+	assert.NotEqual(t, Fingerprint(otherCode), Fingerprint(otherMessage))
+
+	assert.Equal(t, Fingerprint(stderrors.New("disk 1 full")), Fingerprint(stderrors.New("disk 2 full")))
+	assert.Empty(t, Fingerprint(nil))
+}
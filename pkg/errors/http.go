@@ -0,0 +1,61 @@
+package errors
+
+import (
+	"encoding/json"
+	stderrors "errors"
+	"net/http"
+)
+
+// httpErrorBody is the JSON document written for failed HTTP requests
+type httpErrorBody struct {
+	Code        Code            `json:"code"`
+	Message     string          `json:"message"`
+	Description string          `json:"description"`
+	Errors      []httpErrorBody `json:"errors,omitempty"`
+}
+
+// HTTPStatus returns the HTTP status code registered for an error's code
+// Errors without a registered code are reported as 500 Internal Server Error
+func HTTPStatus(err error) int {
+	var coded Error
+	if !stderrors.As(err, &coded) {
+		return http.StatusInternalServerError
+	}
+	return codeHTTPStatus(coded.Code())
+}
+
+// codeHTTPStatus returns the HTTP status of a code, 500 for unknown codes
+func codeHTTPStatus(code Code) int {
+	if info, ok := codeRegistry.lookup(code); ok && info.HTTPStatus != 0 {
+		return info.HTTPStatus
+	}
+	return http.StatusInternalServerError
+}
+
+// newHTTPErrorBody builds the response document for a coded error
+func newHTTPErrorBody(err Error) httpErrorBody {
+	body := httpErrorBody{
+		Code:        err.Code(),
+		Message:     err.Message(),
+		Description: GetCodeDescription(err.Code()),
+	}
+	if multi, ok := err.(*MultiErr); ok {
+		for _, member := range multi.Errors() {
+			body.Errors = append(body.Errors, newHTTPErrorBody(member))
+		}
+	}
+	return body
+}
+
+// WriteHTTPError renders an error as a JSON response with the matching status code
+// Errors without a code are reported as ErrCodeInternal so internal details are not leaked
+func WriteHTTPError(w http.ResponseWriter, err error) {
+	var coded Error
+	if !stderrors.As(err, &coded) {
+		coded = NewErrDefault(ErrCodeInternal, GetCodeDescription(ErrCodeInternal), "")
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(HTTPStatus(coded))
+	_ = json.NewEncoder(w).Encode(newHTTPErrorBody(coded))
+}
@@ -0,0 +1,92 @@
+package errors
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestHTTPStatus tests the mapping from errors to HTTP status codes
+func TestHTTPStatus(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected int
+	}{
+		{name: "not found", err: NewErrDefault(ErrCodeDBNotFound, "missing", "app"), expected: http.StatusNotFound},
+		{name: "permission", err: NewErrDefault(ErrCodePermission, "denied", "app"), expected: http.StatusForbidden},
+		{name: "wrapped code", err: fmt.Errorf("ctx: %w", NewErrDefault(ErrCodeLimit, "slow down", "app")), expected: http.StatusTooManyRequests},
+		{name: "unmapped code", err: NewErrDefault(ErrCodeDatabase, "db", "app"), expected: http.StatusInternalServerError},
+		{name: "plain error", err: fmt.Errorf("boom"), expected: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, HTTPStatus(tt.err))
+		})
+	}
+}
+
+// TestWriteHTTPError tests the JSON rendering of single and aggregated errors
+func TestWriteHTTPError(t *testing.T) {
+	t.Run("single error", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		WriteHTTPError(rec, NewErrDefault(ErrCodeDBNotFound, "user not found", "app"))
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+		var body httpErrorBody
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		assert.Equal(t, ErrCodeDBNotFound, body.Code)
+		assert.Equal(t, "user not found", body.Message)
+		assert.Empty(t, body.Errors)
+	})
+
+	t.Run("multi error", func(t *testing.T) {
+		m := NewMultiErr("app").Append(
+			NewErrDefault(ErrCodeMissingField, "name is required", "app"),
+			NewErrDefault(ErrCodeInvalidFormat, "email is invalid", "app"),
+		)
+		rec := httptest.NewRecorder()
+		WriteHTTPError(rec, m)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+		var body httpErrorBody
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		assert.Equal(t, ErrCodeValidation, body.Code)
+		require.Len(t, body.Errors, 2)
+		assert.Equal(t, ErrCodeMissingField, body.Errors[0].Code)
+		assert.Equal(t, "email is invalid", body.Errors[1].Message)
+	})
+
+	t.Run("multi error with a server failure", func(t *testing.T) {
+		m := NewMultiErr("app").Append(
+			NewErrDefault(ErrCodeDBQuery, "query failed", "app"),
+			NewErrDefault(ErrCodeUnauthorized, "token expired", "app"),
+		)
+		rec := httptest.NewRecorder()
+		WriteHTTPError(rec, m)
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code, "server failures are not reported as client errors")
+
+		var body httpErrorBody
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		assert.Equal(t, ErrCodeDBQuery, body.Code)
+		require.Len(t, body.Errors, 2)
+	})
+
+	t.Run("plain error is not leaked", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		WriteHTTPError(rec, fmt.Errorf("password=hunter2"))
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+		assert.NotContains(t, rec.Body.String(), "hunter2")
+	})
+}
@@ -0,0 +1,215 @@
+package errors
+
+import (
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// catalogFile is the on-disk layout of a message catalog in JSON or YAML
+//
+//	locale: fr
+//	messages:
+//	  "1204": "Enregistrement {id} introuvable"
+type catalogFile struct {
+	Locale   string            `json:"locale" yaml:"locale"`
+	Messages map[string]string `json:"messages" yaml:"messages"`
+}
+
+// Localizer renders user-facing error messages from per-locale message catalogs
+// Messages may contain {name} placeholders that are filled from the error details
+type Localizer struct {
+	mu            sync.RWMutex
+	defaultLocale string
+	catalogs      map[string]map[Code]string // Normalized locale -> code -> message template
+}
+
+// languagePreference is one entry of a parsed Accept-Language header
+type languagePreference struct {
+	tag     string
+	quality float64
+}
+
+// NewLocalizer creates a localizer that falls back to defaultLocale when no requested locale matches
+func NewLocalizer(defaultLocale string) *Localizer {
+	return &Localizer{
+		defaultLocale: normalizeLocale(defaultLocale),
+		catalogs:      make(map[string]map[Code]string),
+	}
+}
+
+// normalizeLocale lower-cases a locale tag and uses "-" as separator (e.g. "en_US" -> "en-us")
+func normalizeLocale(locale string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-"))
+}
+
+// baseLanguage returns the primary language of a locale tag (e.g. "fr-ca" -> "fr")
+func baseLanguage(locale string) string {
+	if i := strings.Index(locale, "-"); i > 0 {
+		return locale[:i]
+	}
+	return locale
+}
+
+// AddMessages adds or replaces message templates for a locale
+func (l *Localizer) AddMessages(locale string, messages map[Code]string) {
+	locale = normalizeLocale(locale)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	catalog, ok := l.catalogs[locale]
+	if !ok {
+		catalog = make(map[Code]string, len(messages))
+		l.catalogs[locale] = catalog
+	}
+	for code, msg := range messages {
+		catalog[code] = msg
+	}
+}
+
+// LoadFile loads a message catalog from a .json, .yaml or .yml file
+// The file must name its locale and map error codes to message templates
+func (l *Localizer) LoadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return NewErr(ErrCodeConfigFile, err, fmt.Sprintf("failed to read message catalog %s", path), "errors")
+	}
+
+	var catalog catalogFile
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		err = json.Unmarshal(data, &catalog)
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &catalog)
+	default:
+		return NewErrDefault(ErrCodeInvalidFormat, fmt.Sprintf("unsupported message catalog format: %s", path), "errors")
+	}
+	if err != nil {
+		return NewErr(ErrCodeConfigFile, err, fmt.Sprintf("failed to parse message catalog %s", path), "errors")
+	}
+	if catalog.Locale == "" {
+		return NewErrDefault(ErrCodeConfigMissing, fmt.Sprintf("message catalog %s has no locale", path), "errors")
+	}
+
+	messages := make(map[Code]string, len(catalog.Messages))
+	for code, msg := range catalog.Messages {
+		messages[Code(code)] = msg
+	}
+	l.AddMessages(catalog.Locale, messages)
+	return nil
+}
+
+// Message returns the message for a code in the best locale for an Accept-Language value
+// Falls back to the default locale and finally to the registered code description
+func (l *Localizer) Message(code Code, acceptLanguage string, params map[string]interface{}) string {
+	l.mu.RLock()
+	template, ok := l.lookup(code, acceptLanguage)
+	l.mu.RUnlock()
+
+	if !ok {
+		template = GetCodeDescription(code)
+	}
+	return substituteParams(template, params)
+}
+
+// Localize returns the localized message for an error, using its details as parameters
+// Errors without a code are rendered as ErrCodeUnknown
+func (l *Localizer) Localize(err error, acceptLanguage string) string {
+	code := ErrCodeUnknown
+	var params map[string]interface{}
+
+	var coded *Err
+	if stderrors.As(err, &coded) {
+		code = coded.Code()
+		params = coded.Details()
+	}
+	return l.Message(code, acceptLanguage, params)
+}
+
+// lookup finds a template following the fallback order: each requested locale, its base
+// language, then the default locale and its base language. Callers must hold the read lock
+func (l *Localizer) lookup(code Code, acceptLanguage string) (string, bool) {
+	var candidates []string
+	for _, pref := range parseAcceptLanguage(acceptLanguage) {
+		candidates = append(candidates, pref.tag, baseLanguage(pref.tag))
+	}
+	candidates = append(candidates, l.defaultLocale, baseLanguage(l.defaultLocale))
+
+	for _, locale := range candidates {
+		if msg, ok := l.catalogs[locale][code]; ok {
+			return msg, true
+		}
+	}
+	return "", false
+}
+
+// parseAcceptLanguage parses an Accept-Language value into tags ordered by preference
+// Wildcards and entries with zero quality are dropped
+func parseAcceptLanguage(header string) []languagePreference {
+	var prefs []languagePreference
+	for _, part := range strings.Split(header, ",") {
+		fields := strings.Split(part, ";")
+		tag := normalizeLocale(fields[0])
+		if tag == "" || tag == "*" {
+			continue
+		}
+
+		quality := 1.0
+		for _, param := range fields[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "q=") {
+				if q, err := strconv.ParseFloat(param[2:], 64); err == nil {
+					quality = q
+				}
+			}
+		}
+		if quality <= 0 {
+			continue
+		}
+		prefs = append(prefs, languagePreference{tag: tag, quality: quality})
+	}
+
+	// Stable sort keeps header order for tags with equal quality
+	sort.SliceStable(prefs, func(i, j int) bool { return prefs[i].quality > prefs[j].quality })
+	return prefs
+}
+
+// substituteParams replaces {name} placeholders with parameter values
+// Placeholders without a matching parameter are left untouched
+func substituteParams(template string, params map[string]interface{}) string {
+	if len(params) == 0 || !strings.Contains(template, "{") {
+		return template
+	}
+
+	var b strings.Builder
+	for {
+		start := strings.Index(template, "{")
+		if start < 0 {
+			break
+		}
+		end := strings.Index(template[start:], "}")
+		if end < 0 {
+			break
+		}
+		end += start
+
+		b.WriteString(template[:start])
+		if value, ok := params[template[start+1:end]]; ok {
+			b.WriteString(fmt.Sprint(value))
+		} else {
+			b.WriteString(template[start : end+1])
+		}
+		template = template[end+1:]
+	}
+	b.WriteString(template)
+	return b.String()
+}
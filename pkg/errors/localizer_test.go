@@ -0,0 +1,163 @@
+package errors
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestLocalizer creates a localizer with English, French and Canadian French catalogs
+func newTestLocalizer() *Localizer {
+	l := NewLocalizer("en")
+	l.AddMessages("en", map[Code]string{
+		ErrCodeDBNotFound: "Record {id} was not found",
+		ErrCodeTimeout:    "The request timed out",
+	})
+	l.AddMessages("fr", map[Code]string{
+		ErrCodeDBNotFound: "Enregistrement {id} introuvable",
+	})
+	l.AddMessages("fr_CA", map[Code]string{
+		ErrCodeDBNotFound: "Fiche {id} introuvable",
+	})
+	return l
+}
+
+// TestLocalizerMessage tests locale selection and fallback rules
+func TestLocalizerMessage(t *testing.T) {
+	tests := []struct {
+		name           string
+		code           Code
+		acceptLanguage string
+		expected       string
+	}{
+		{
+			name:           "exact locale match",
+			code:           ErrCodeDBNotFound,
+			acceptLanguage: "fr-CA",
+			expected:       "Fiche 42 introuvable",
+		},
+		{
+			name:           "falls back to base language",
+			code:           ErrCodeDBNotFound,
+			acceptLanguage: "fr-BE",
+			expected:       "Enregistrement 42 introuvable",
+		},
+		{
+			name:           "quality ordering",
+			code:           ErrCodeDBNotFound,
+			acceptLanguage: "de;q=0.9, fr;q=0.5, en;q=0.1",
+			expected:       "Enregistrement 42 introuvable",
+		},
+		{
+			name:           "zero quality is ignored",
+			code:           ErrCodeDBNotFound,
+			acceptLanguage: "fr;q=0, de",
+			expected:       "Record 42 was not found",
+		},
+		{
+			name:           "missing translation falls back to default locale",
+			code:           ErrCodeTimeout,
+			acceptLanguage: "fr",
+			expected:       "The request timed out",
+		},
+		{
+			name:           "wildcard falls back to default locale",
+			code:           ErrCodeDBNotFound,
+			acceptLanguage: "*",
+			expected:       "Record 42 was not found",
+		},
+		{
+			name:           "no catalog entry falls back to code description",
+			code:           ErrCodeDBQuery,
+			acceptLanguage: "fr",
+			expected:       GetCodeDescription(ErrCodeDBQuery),
+		},
+	}
+
+	l := newTestLocalizer()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := l.Message(tt.code, tt.acceptLanguage, map[string]interface{}{"id": 42})
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+// TestLocalizerLocalize tests that error details are used as message parameters
+func TestLocalizerLocalize(t *testing.T) {
+	l := newTestLocalizer()
+
+	err := NewErrDefault(ErrCodeDBNotFound, "user not found", "testapp").WithDetail("id", "u-1")
+	assert.Equal(t, "Enregistrement u-1 introuvable", l.Localize(err, "fr"))
+
+	wrapped := fmt.Errorf("lookup failed: %w", err)
+	assert.Equal(t, "Record u-1 was not found", l.Localize(wrapped, "en-US"))
+
+	// Context added with Wrapf keeps the details of the wrapped error
+	withContext := Wrapf(err, "load user")
+	assert.Equal(t, "Record u-1 was not found", l.Localize(withContext, "en-US"))
+	assert.Equal(t, "Record u-1 was not found", l.Localize(Wrapf(withContext, "handler"), "en-US"))
+
+	assert.Equal(t, GetCodeDescription(ErrCodeUnknown), l.Localize(fmt.Errorf("plain"), "en"))
+}
+
+// TestSubstituteParams tests placeholder replacement
+func TestSubstituteParams(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		params   map[string]interface{}
+		expected string
+	}{
+		{name: "single placeholder", template: "Hello {name}", params: map[string]interface{}{"name": "Ada"}, expected: "Hello Ada"},
+		{name: "multiple placeholders", template: "{a}+{b}={c}", params: map[string]interface{}{"a": 1, "b": 2, "c": 3}, expected: "1+2=3"},
+		{name: "unknown placeholder is kept", template: "Hello {who}", params: map[string]interface{}{"name": "Ada"}, expected: "Hello {who}"},
+		{name: "unterminated placeholder", template: "Hello {name", params: map[string]interface{}{"name": "Ada"}, expected: "Hello {name"},
+		{name: "no params", template: "Hello {name}", params: nil, expected: "Hello {name}"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, substituteParams(tt.template, tt.params))
+		})
+	}
+}
+
+// TestLocalizerLoadFile tests loading catalogs from JSON and YAML files
+func TestLocalizerLoadFile(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"de.json":  `{"locale": "de", "messages": {"1204": "Datensatz {id} nicht gefunden"}}`,
+		"es.yaml":  "locale: es\nmessages:\n  \"1204\": \"Registro {id} no encontrado\"\n",
+		"bad.json": `{"locale": `,
+		"none.yml": "messages:\n  \"1204\": \"x\"\n",
+		"cat.txt":  "locale: it",
+	}
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600))
+	}
+
+	l := NewLocalizer("en")
+	require.NoError(t, l.LoadFile(filepath.Join(dir, "de.json")))
+	require.NoError(t, l.LoadFile(filepath.Join(dir, "es.yaml")))
+
+	params := map[string]interface{}{"id": 7}
+	assert.Equal(t, "Datensatz 7 nicht gefunden", l.Message(ErrCodeDBNotFound, "de-DE", params))
+	assert.Equal(t, "Registro 7 no encontrado", l.Message(ErrCodeDBNotFound, "es", params))
+
+	errorCases := map[string]Code{
+		"bad.json":     ErrCodeConfigFile,
+		"none.yml":     ErrCodeConfigMissing,
+		"cat.txt":      ErrCodeInvalidFormat,
+		"missing.json": ErrCodeConfigFile,
+	}
+	for name, code := range errorCases {
+		err := l.LoadFile(filepath.Join(dir, name))
+		require.Error(t, err, name)
+		assert.Equal(t, code, err.(*Err).Code(), name)
+	}
+}
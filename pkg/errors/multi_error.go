@@ -0,0 +1,184 @@
+package errors
+
+import (
+	stderrors "errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// MultiErr aggregates several coded errors, e.g. every validation failure of a request
+// or every problem found while loading configuration
+type MultiErr struct {
+	errs []*Err
+	app  string
+}
+
+// NewMultiErr creates an empty aggregate for the given application
+func NewMultiErr(app string) *MultiErr {
+	return &MultiErr{app: app}
+}
+
+// Append adds errors to the aggregate and returns it for chaining
+// Nil errors and duplicates are skipped, nested MultiErr values are flattened
+// and errors without a code are kept as ErrCodeUnknown
+func (m *MultiErr) Append(errs ...error) *MultiErr {
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+
+		var multi *MultiErr
+		if stderrors.As(err, &multi) && multi != m {
+			for _, member := range multi.errs {
+				m.add(member)
+			}
+			continue
+		}
+
+		var coded *Err
+		if !stderrors.As(err, &coded) {
+			coded = NewErr(ErrCodeUnknown, err, err.Error(), m.app)
+		}
+		m.add(coded)
+	}
+	return m
+}
+
+// add appends a member unless an identical error is already present
+func (m *MultiErr) add(err *Err) {
+	for _, existing := range m.errs {
+		if existing.Code() == err.Code() && existing.Message() == err.Message() && existing.Error() == err.Error() {
+			return
+		}
+	}
+	m.errs = append(m.errs, err)
+}
+
+// Errors returns the collected errors in the order they were added
+func (m *MultiErr) Errors() []*Err {
+	errs := make([]*Err, len(m.errs))
+	copy(errs, m.errs)
+	return errs
+}
+
+// App returns the name of the application the aggregate was created for
+func (m *MultiErr) App() string {
+	return m.app
+}
+
+// Len returns the number of collected errors
+func (m *MultiErr) Len() int {
+	return len(m.errs)
+}
+
+// ErrorOrNil returns nil when no errors were collected, which avoids returning
+// a non-nil interface holding an empty aggregate
+func (m *MultiErr) ErrorOrNil() error {
+	if m == nil || len(m.errs) == 0 {
+		return nil
+	}
+	return m
+}
+
+// Code computes the overall code of the aggregate:
+// the shared code if all members agree, the base code of their category
+// if they share one, ErrCodeValidation if every member is a client error (4xx),
+// and otherwise the code of the member with the highest HTTP status, so server
+// failures are never reported to clients as their fault
+func (m *MultiErr) Code() Code {
+	if len(m.errs) == 0 {
+		return ErrCodeUnknown
+	}
+
+	first := m.errs[0].Code()
+	sameCode, sameCategory := true, true
+	category, _ := codeCategory(first)
+	for _, err := range m.errs[1:] {
+		if err.Code() != first {
+			sameCode = false
+		}
+		if c, _ := codeCategory(err.Code()); c != category {
+			sameCategory = false
+		}
+	}
+
+	switch {
+	case sameCode:
+		return first
+	case sameCategory && category != "":
+		if code, ok := codeRegistry.baseCode(category); ok {
+			return code
+		}
+	}
+	return m.mostSeriousCode()
+}
+
+// mostSeriousCode returns ErrCodeValidation if every member is a client error,
+// otherwise the code of the first member with the highest HTTP status
+func (m *MultiErr) mostSeriousCode() Code {
+	code, highest := ErrCodeValidation, 0
+	for _, err := range m.errs {
+		status := codeHTTPStatus(err.Code())
+		if status >= http.StatusInternalServerError && status > highest {
+			code, highest = err.Code(), status
+		}
+	}
+	return code
+}
+
+// codeCategory returns the registered category of a code
+func codeCategory(code Code) (Category, bool) {
+	info, ok := codeRegistry.lookup(code)
+	return info.Category, ok
+}
+
+// Message summarizes the aggregate
+func (m *MultiErr) Message() string {
+	if len(m.errs) == 1 {
+		return "1 error occurred"
+	}
+	return fmt.Sprintf("%d errors occurred", len(m.errs))
+}
+
+// Cause returns the root cause of the first collected error
+func (m *MultiErr) Cause() error {
+	if len(m.errs) == 0 {
+		return nil
+	}
+	return m.errs[0].Cause()
+}
+
+// Er returns the collected errors joined into a single error
+func (m *MultiErr) Er() error {
+	return stderrors.Join(m.Unwrap()...)
+}
+
+// Wrap annotates the aggregate with a message while keeping it reachable through errors.As
+func (m *MultiErr) Wrap(msg string) error {
+	return fmt.Errorf("%s: %w", msg, m)
+}
+
+// Unwrap exposes every member to the standard errors.Is and errors.As functions
+func (m *MultiErr) Unwrap() []error {
+	errs := make([]error, len(m.errs))
+	for i, err := range m.errs {
+		errs[i] = err
+	}
+	return errs
+}
+
+// Error renders the aggregate as a list with one line per member
+func (m *MultiErr) Error() string {
+	var b strings.Builder
+	b.WriteString(m.Message())
+	b.WriteString(":")
+	for _, err := range m.errs {
+		msg := err.Error()
+		if msg == "" {
+			msg = err.Message()
+		}
+		fmt.Fprintf(&b, "\n\t* [%s] %s", err.Code(), msg)
+	}
+	return b.String()
+}
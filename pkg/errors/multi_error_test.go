@@ -0,0 +1,133 @@
+package errors
+
+import (
+	stderrors "errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestMultiErrAppend tests collection, de-duplication and flattening
+func TestMultiErrAppend(t *testing.T) {
+	email := NewErrDefault(ErrCodeInvalidFormat, "email is invalid", "signup")
+	name := NewErrDefault(ErrCodeMissingField, "name is required", "signup")
+
+	nested := NewMultiErr("signup").Append(name)
+	m := NewMultiErr("signup").Append(
+		email,
+		nil,
+		NewErrDefault(ErrCodeInvalidFormat, "email is invalid", "signup"), // Duplicate of email
+		nested,
+		fmt.Errorf("plain failure"),
+	)
+
+	require.Equal(t, 3, m.Len())
+	assert.Equal(t, "signup", m.App())
+	members := m.Errors()
+	assert.Same(t, email, members[0])
+	assert.Same(t, name, members[1])
+	assert.Equal(t, ErrCodeUnknown, members[2].Code())
+	assert.Equal(t, "plain failure", members[2].Error())
+}
+
+// TestMultiErrCode tests the overall code computation
+func TestMultiErrCode(t *testing.T) {
+	tests := []struct {
+		name     string
+		errs     []error
+		expected Code
+	}{
+		{
+			name:     "empty aggregate",
+			expected: ErrCodeUnknown,
+		},
+		{
+			name:     "single member",
+			errs:     []error{NewErrDefault(ErrCodeDBQuery, "query failed", "app")},
+			expected: ErrCodeDBQuery,
+		},
+		{
+			name: "same code",
+			errs: []error{
+				NewErrDefault(ErrCodeMissingField, "name is required", "app"),
+				NewErrDefault(ErrCodeMissingField, "email is required", "app"),
+			},
+			expected: ErrCodeMissingField,
+		},
+		{
+			name: "same category",
+			errs: []error{
+				NewErrDefault(ErrCodeConfigMissing, "port missing", "app"),
+				NewErrDefault(ErrCodeConfigType, "timeout is not a duration", "app"),
+			},
+			expected: ErrCodeConfig,
+		},
+		{
+			name: "mixed client errors",
+			errs: []error{
+				NewErrDefault(ErrCodeUnauthorized, "token expired", "app"),
+				NewErrDefault(ErrCodeMissingField, "name is required", "app"),
+			},
+			expected: ErrCodeValidation,
+		},
+		{
+			name: "mixed with a server error",
+			errs: []error{
+				NewErrDefault(ErrCodeMissingField, "name is required", "app"),
+				NewErrDefault(ErrCodeDBQuery, "query failed", "app"),
+			},
+			expected: ErrCodeDBQuery,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewMultiErr("app").Append(tt.errs...)
+			assert.Equal(t, tt.expected, m.Code())
+		})
+	}
+}
+
+// TestMultiErrIsAs tests that errors.Is and errors.As see every member
+func TestMultiErrIsAs(t *testing.T) {
+	sentinel := stderrors.New("disk full")
+	timeout := NewErr(ErrCodeTimeout, sentinel, "write timed out", "app")
+
+	m := NewMultiErr("app").Append(NewErrDefault(ErrCodeMissingField, "name is required", "app"), timeout)
+	wrapped := m.Wrap("saving profile")
+
+	assert.True(t, stderrors.Is(wrapped, sentinel))
+	assert.True(t, stderrors.Is(m.Er(), sentinel))
+
+	var multi *MultiErr
+	require.True(t, stderrors.As(wrapped, &multi))
+	assert.Same(t, m, multi)
+
+	var coded *Err
+	require.True(t, stderrors.As(m, &coded))
+	assert.Equal(t, ErrCodeMissingField, coded.Code())
+}
+
+// TestMultiErrRendering tests the list rendering and interface methods
+func TestMultiErrRendering(t *testing.T) {
+	m := NewMultiErr("app").Append(
+		NewErrDefault(ErrCodeMissingField, "name is required", "app"),
+		NewErr(ErrCodeInvalidInput, nil, "age must be positive", "app"),
+	)
+
+	var _ Error = m
+	assert.Equal(t, "2 errors occurred:\n\t* [1403] name is required\n\t* [1401] age must be positive", m.Error())
+	assert.Equal(t, "2 errors occurred", m.Message())
+	assert.Equal(t, "name is required", m.Cause().Error())
+}
+
+// TestMultiErrErrorOrNil tests the nil-returning helper
+func TestMultiErrErrorOrNil(t *testing.T) {
+	m := NewMultiErr("app")
+	assert.Nil(t, m.ErrorOrNil())
+
+	m.Append(NewErrDefault(ErrCodeValidation, "invalid", "app"))
+	assert.Equal(t, m, m.ErrorOrNil())
+}
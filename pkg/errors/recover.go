@@ -0,0 +1,150 @@
+package errors
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"net/http"
+	"runtime/debug"
+	"sync"
+)
+
+// Panic reporting state, set by the logger package so recovered panics are logged
+var (
+	panicHandlerMu sync.RWMutex
+	panicHandler   func(err *Err)
+)
+
+// SetPanicHandler registers the function that reports panics recovered by Recover,
+// SafeGo and RecoverMiddleware. The logger package installs a handler that logs
+// through logger.Error; pass nil to disable reporting
+func SetPanicHandler(handler func(err *Err)) {
+	panicHandlerMu.Lock()
+	defer panicHandlerMu.Unlock()
+	panicHandler = handler
+}
+
+// reportPanic hands a recovered panic to the registered handler
+// A panicking handler must not take down the goroutine that just recovered
+func reportPanic(err *Err) {
+	panicHandlerMu.RLock()
+	handler := panicHandler
+	panicHandlerMu.RUnlock()
+
+	if handler == nil {
+		return
+	}
+	defer func() { _ = recover() }()
+	handler(err)
+}
+
+// NewPanicErr converts a recovered panic value into an ErrCodeInternal error
+// The panic value and stack are attached as the "panic" and "stack" details
+func NewPanicErr(value interface{}, stack []byte) *Err {
+	er, ok := value.(error)
+	if ok {
+		er = fmt.Errorf("panic: %w", er)
+	} else {
+		er = fmt.Errorf("panic: %v", value)
+	}
+	return NewErr(ErrCodeInternal, er, "recovered from panic", "").
+		WithDetail("panic", fmt.Sprint(value)).
+		WithDetail("stack", string(stack))
+}
+
+// Recover converts a panic into a coded error and reports it
+// It must be deferred directly: defer errors.Recover(&err)
+// The error is stored in *errp when errp is not nil
+func Recover(errp *error) {
+	value := recover()
+	if value == nil {
+		return
+	}
+
+	err := NewPanicErr(value, debug.Stack())
+	reportPanic(err)
+	if errp != nil {
+		*errp = err
+	}
+}
+
+// SafeGo runs fn in a new goroutine, converting and reporting a panic instead of
+// letting it crash the process
+func SafeGo(fn func()) {
+	go func() {
+		defer Recover(nil)
+		fn()
+	}()
+}
+
+// RecoverMiddleware recovers panics raised by an HTTP handler, reports them and
+// answers with a 500 JSON error. If the handler had already started its response, the
+// panic is only reported and the response aborted with http.ErrAbortHandler, as a
+// second status line would corrupt it. http.ErrAbortHandler raised by the handler is
+// re-raised so net/http can abort the response as intended
+func RecoverMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tw := &trackingWriter{ResponseWriter: w}
+		defer func() {
+			value := recover()
+			if value == nil {
+				return
+			}
+			if value == http.ErrAbortHandler {
+				panic(value)
+			}
+
+			err := NewPanicErr(value, debug.Stack()).
+				WithDetail("method", r.Method).
+				WithDetail("path", r.URL.Path)
+			reportPanic(err)
+			if tw.started {
+				panic(http.ErrAbortHandler)
+			}
+			WriteHTTPError(w, err)
+		}()
+		next.ServeHTTP(tw, r)
+	})
+}
+
+// trackingWriter records whether a handler started its response
+type trackingWriter struct {
+	http.ResponseWriter
+	started bool // Headers or body were sent
+}
+
+// WriteHeader sends the status; informational 1xx statuses do not start the response
+func (w *trackingWriter) WriteHeader(status int) {
+	if status >= http.StatusOK {
+		w.started = true
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *trackingWriter) Write(b []byte) (int, error) {
+	w.started = true
+	return w.ResponseWriter.Write(b)
+}
+
+// Flush implements http.Flusher for handlers streaming their response
+func (w *trackingWriter) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		w.started = true
+		flusher.Flush()
+	}
+}
+
+// Hijack implements http.Hijacker for handlers taking over the connection, e.g. WebSockets
+func (w *trackingWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, http.ErrNotSupported
+	}
+	w.started = true
+	return hijacker.Hijack()
+}
+
+// Unwrap returns the wrapped writer, for http.ResponseController
+func (w *trackingWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
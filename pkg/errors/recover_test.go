@@ -0,0 +1,167 @@
+package errors
+
+import (
+	"encoding/json"
+	stderrors "errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// capturePanics installs a panic handler that records reported errors for the test
+func capturePanics(t *testing.T) <-chan *Err {
+	reported := make(chan *Err, 10)
+	SetPanicHandler(func(err *Err) { reported <- err })
+	t.Cleanup(func() { SetPanicHandler(nil) })
+	return reported
+}
+
+// TestRecover tests that a panic becomes a coded error with value and stack
+func TestRecover(t *testing.T) {
+	reported := capturePanics(t)
+
+	run := func() (err error) {
+		defer Recover(&err)
+		panic("boom")
+	}
+	err := run()
+
+	require.Error(t, err)
+	var coded *Err
+	require.True(t, stderrors.As(err, &coded))
+	assert.Equal(t, ErrCodeInternal, coded.Code())
+	assert.Equal(t, "panic: boom", coded.Error())
+	assert.Equal(t, "boom", coded.Details()["panic"])
+	assert.Contains(t, coded.Details()["stack"], "TestRecover")
+
+	select {
+	case r := <-reported:
+		assert.Same(t, coded, r)
+	default:
+		t.Fatal("panic should be reported")
+	}
+}
+
+// TestRecoverWithoutPanic tests that Recover leaves the error untouched without a panic
+func TestRecoverWithoutPanic(t *testing.T) {
+	sentinel := stderrors.New("regular failure")
+	run := func() (err error) {
+		defer Recover(&err)
+		return sentinel
+	}
+	assert.Same(t, sentinel, run())
+}
+
+// TestRecoverErrorValue tests that panicking with an error keeps it in the chain
+func TestRecoverErrorValue(t *testing.T) {
+	sentinel := stderrors.New("bad state")
+	run := func() (err error) {
+		defer Recover(&err)
+		panic(sentinel)
+	}
+	assert.True(t, stderrors.Is(run(), sentinel))
+}
+
+// TestRecoverPanickingHandler tests that a failing panic handler is contained
+func TestRecoverPanickingHandler(t *testing.T) {
+	SetPanicHandler(func(err *Err) { panic("handler failed") })
+	t.Cleanup(func() { SetPanicHandler(nil) })
+
+	run := func() (err error) {
+		defer Recover(&err)
+		panic("boom")
+	}
+	assert.NotPanics(t, func() { assert.Error(t, run()) })
+}
+
+// TestSafeGo tests that a panicking goroutine is recovered and reported
+func TestSafeGo(t *testing.T) {
+	reported := capturePanics(t)
+
+	SafeGo(func() { panic("worker crashed") })
+
+	err := <-reported
+	assert.Equal(t, ErrCodeInternal, err.Code())
+	assert.Equal(t, "worker crashed", err.Details()["panic"])
+}
+
+// TestRecoverMiddleware tests that handler panics are rendered as 500 responses
+func TestRecoverMiddleware(t *testing.T) {
+	reported := capturePanics(t)
+
+	handler := RecoverMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("handler crashed")
+	}))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/orders", nil))
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	var body httpErrorBody
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, ErrCodeInternal, body.Code)
+	assert.NotContains(t, rec.Body.String(), "handler crashed", "panic values must not leak to clients")
+
+	err := <-reported
+	assert.Equal(t, "/orders", err.Details()["path"])
+	assert.Equal(t, http.MethodGet, err.Details()["method"])
+}
+
+// TestRecoverMiddlewareStartedResponse tests that a panic after the response started only aborts it
+func TestRecoverMiddlewareStartedResponse(t *testing.T) {
+	tests := []struct {
+		name  string
+		write func(w http.ResponseWriter)
+	}{
+		{name: "header", write: func(w http.ResponseWriter) { w.WriteHeader(http.StatusAccepted) }},
+		{name: "body", write: func(w http.ResponseWriter) { _, _ = w.Write([]byte(`{"items":[`)) }},
+		{name: "flush", write: func(w http.ResponseWriter) { w.(http.Flusher).Flush() }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reported := capturePanics(t)
+			handler := RecoverMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				tt.write(w)
+				panic("handler crashed")
+			}))
+			rec := httptest.NewRecorder()
+
+			assert.PanicsWithValue(t, http.ErrAbortHandler, func() {
+				handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/orders", nil))
+			})
+			assert.NotEqual(t, http.StatusInternalServerError, rec.Code)
+			assert.NotContains(t, rec.Body.String(), string(ErrCodeInternal), "no error body is appended")
+			assert.Equal(t, "/orders", (<-reported).Details()["path"], "the panic is still reported")
+		})
+	}
+
+	// Informational responses do not start the response
+	early := RecoverMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusEarlyHints)
+		panic("handler crashed")
+	}))
+	capturePanics(t)
+	rec := httptest.NewRecorder()
+	early.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Contains(t, rec.Body.String(), string(ErrCodeInternal))
+}
+
+// TestRecoverMiddlewarePassThrough tests that normal responses and aborts are untouched
+func TestRecoverMiddlewarePassThrough(t *testing.T) {
+	ok := RecoverMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	rec := httptest.NewRecorder()
+	ok.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	abort := RecoverMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic(http.ErrAbortHandler)
+	}))
+	assert.PanicsWithValue(t, http.ErrAbortHandler, func() {
+		abort.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	})
+}
@@ -0,0 +1,392 @@
+package errors
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// CodeOptions carries optional metadata attached to a code when it is registered
+type CodeOptions struct {
+	Name       string   // Constant or symbolic name of the code (e.g. "ErrCodeDatabase"), used in documentation
+	HTTPStatus int      // HTTP status returned to API clients, the category default or 500 if unset
+	Retryable  *bool    // Whether failures with this code are transient and worth retrying, the category default if nil
+	Severity   Severity // How serious failures with this code are, the category default if unset
+}
+
+// Bool returns a pointer to a bool, for optional options such as CodeOptions.Retryable
+func Bool(value bool) *bool {
+	return &value
+}
+
+// CodeInfo describes a registered error code
+type CodeInfo struct {
+	Code        Code     // The error code itself
+	Name        string   // Symbolic name of the code, if one was provided
+	Category    Category // Canonical category the code belongs to
+	Description string   // Human-readable description of the code
+	HTTPStatus  int      // HTTP status returned to API clients
+	Retryable   bool     // Whether failures with this code are transient and worth retrying
+	Severity    Severity // How serious failures with this code are
+}
+
+// codeRange is a reserved, inclusive number range owned by a single category
+type codeRange struct {
+	category Category
+	min      int
+	max      int
+	aliases  []string
+	defaults CategoryDefaults
+}
+
+// registry holds every known error code together with the category ranges they live in
+// All access goes through the mutex so lookups are safe while applications register codes
+type registry struct {
+	mu      sync.RWMutex
+	codes   map[Code]CodeInfo
+	ranges  []codeRange
+	aliases map[string]Category // Lower-cased category name or alias -> canonical category
+}
+
+// builtinCategory describes a category shipped with the library and the range it owns
+type builtinCategory struct {
+	name     Category
+	min      int
+	max      int
+	aliases  []string
+	defaults CategoryDefaults
+}
+
+// codeRegistry is the process-wide registry seeded with the built-in codes
+var codeRegistry = newBuiltinRegistry()
+
+// newRegistry creates an empty registry without any reserved ranges
+func newRegistry() *registry {
+	return &registry{
+		codes:   make(map[Code]CodeInfo),
+		aliases: make(map[string]Category),
+	}
+}
+
+// newBuiltinRegistry creates a registry holding the built-in categories and codes
+// A failure here means the built-in tables are inconsistent, so it panics at startup
+func newBuiltinRegistry() *registry {
+	r := newRegistry()
+	for _, c := range builtinCategories {
+		if err := r.reserveCategory(c.name, c.min, c.max, c.defaults, c.aliases...); err != nil {
+			panic("errors: invalid built-in category: " + err.Error())
+		}
+	}
+	for code, desc := range CodeDetails {
+		category, ok := r.categoryForNumber(code)
+		if !ok {
+			panic("errors: built-in code outside any category: " + string(code))
+		}
+		if err := r.register(code, category, desc, builtinCodeOptions[code]); err != nil {
+			panic("errors: invalid built-in code: " + err.Error())
+		}
+	}
+	return r
+}
+
+// parseCodeNumber converts a code to its numeric value
+func parseCodeNumber(code Code) (int, bool) {
+	n, err := strconv.Atoi(string(code))
+	if err != nil {
+		return 0, false
+	}
+	return n, true
+}
+
+// canonicalCategory resolves a category name or alias to its canonical name
+// Callers must hold at least the read lock
+func (r *registry) canonicalCategory(category Category) (Category, bool) {
+	name, ok := r.aliases[strings.ToLower(strings.TrimSpace(string(category)))]
+	return name, ok
+}
+
+// rangeOf returns the reserved range of a canonical category
+// Callers must hold at least the read lock
+func (r *registry) rangeOf(category Category) (codeRange, bool) {
+	for _, rg := range r.ranges {
+		if rg.category == category {
+			return rg, true
+		}
+	}
+	return codeRange{}, false
+}
+
+// categoryForNumber finds the category whose range contains the given code
+// Callers must hold at least the read lock
+func (r *registry) categoryForNumber(code Code) (Category, bool) {
+	n, ok := parseCodeNumber(code)
+	if !ok {
+		return "", false
+	}
+	for _, rg := range r.ranges {
+		if n >= rg.min && n <= rg.max {
+			return rg.category, true
+		}
+	}
+	return "", false
+}
+
+// reserveRange claims an inclusive number range for a new category without defaults
+func (r *registry) reserveRange(category Category, min, max int, aliases ...string) error {
+	return r.reserveCategory(category, min, max, CategoryDefaults{}, aliases...)
+}
+
+// reserveCategory claims an inclusive number range for a new category and records
+// the defaults applied to the codes registered in it
+func (r *registry) reserveCategory(category Category, min, max int, defaults CategoryDefaults, aliases ...string) error {
+	name := Category(strings.ToLower(strings.TrimSpace(string(category))))
+	if name == "" {
+		return NewErrDefault(ErrCodeInvalidInput, "category name must not be empty", "errors")
+	}
+	if min < 0 || min > max {
+		return NewErrDefault(ErrCodeInvalidInput,
+			fmt.Sprintf("invalid range %d-%d for category %q", min, max, name), "errors")
+	}
+	if defaults.HTTPStatus != 0 && (defaults.HTTPStatus < 100 || defaults.HTTPStatus > 599) {
+		return NewErrDefault(ErrCodeInvalidInput,
+			fmt.Sprintf("invalid default HTTP status %d for category %q", defaults.HTTPStatus, name), "errors")
+	}
+	if defaults.Severity != "" && !defaults.Severity.valid() {
+		return NewErrDefault(ErrCodeInvalidInput,
+			fmt.Sprintf("invalid default severity %q for category %q", defaults.Severity, name), "errors")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	names := append([]string{string(name)}, aliases...)
+	for _, n := range names {
+		if _, exists := r.aliases[strings.ToLower(n)]; exists {
+			return NewErrDefault(ErrCodeConflict,
+				fmt.Sprintf("category %q is already registered", n), "errors")
+		}
+	}
+	for _, rg := range r.ranges {
+		if min <= rg.max && max >= rg.min {
+			return NewErrDefault(ErrCodeConflict,
+				fmt.Sprintf("range %d-%d overlaps category %q (%d-%d)", min, max, rg.category, rg.min, rg.max), "errors")
+		}
+	}
+
+	r.ranges = append(r.ranges, codeRange{
+		category: name,
+		min:      min,
+		max:      max,
+		aliases:  append([]string(nil), aliases...),
+		defaults: defaults,
+	})
+	sort.Slice(r.ranges, func(i, j int) bool { return r.ranges[i].min < r.ranges[j].min })
+	for _, n := range names {
+		r.aliases[strings.ToLower(n)] = name
+	}
+	return nil
+}
+
+// register adds a code to a category, validating it against the category's range
+// HTTP status, retryability and severity not set in opts are taken from the category defaults
+func (r *registry) register(code Code, category Category, description string, opts CodeOptions) error {
+	n, ok := parseCodeNumber(code)
+	if !ok {
+		return NewErrDefault(ErrCodeInvalidFormat,
+			fmt.Sprintf("error code %q is not numeric", code), "errors")
+	}
+
+	if opts.Severity != "" && !opts.Severity.valid() {
+		return NewErrDefault(ErrCodeInvalidInput,
+			fmt.Sprintf("invalid severity %q for error code %s", opts.Severity, code), "errors")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	name, ok := r.canonicalCategory(category)
+	if !ok {
+		return NewErrDefault(ErrCodeInvalidInput,
+			fmt.Sprintf("category %q has no reserved range", category), "errors")
+	}
+	if _, exists := r.codes[code]; exists {
+		return NewErrDefault(ErrCodeConflict,
+			fmt.Sprintf("error code %s is already registered", code), "errors")
+	}
+	rg, _ := r.rangeOf(name)
+	if n < rg.min || n > rg.max {
+		return NewErrDefault(ErrCodeInvalidInput,
+			fmt.Sprintf("error code %s is outside the %d-%d range of category %q", code, rg.min, rg.max, name), "errors")
+	}
+
+	status := opts.HTTPStatus
+	if status == 0 {
+		status = rg.defaults.HTTPStatus
+	}
+	if status == 0 {
+		status = http.StatusInternalServerError
+	}
+	severity := opts.Severity
+	if severity == "" {
+		severity = rg.defaults.Severity
+	}
+	retryable := rg.defaults.Retryable
+	if opts.Retryable != nil {
+		retryable = *opts.Retryable
+	}
+	r.codes[code] = CodeInfo{
+		Code:        code,
+		Name:        opts.Name,
+		Category:    name,
+		Description: description,
+		HTTPStatus:  status,
+		Retryable:   retryable,
+		Severity:    severity.orDefault(),
+	}
+	return nil
+}
+
+// lookup returns the registration details of a code
+func (r *registry) lookup(code Code) (CodeInfo, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	info, ok := r.codes[code]
+	return info, ok
+}
+
+// all returns every registered code in ascending order
+func (r *registry) all() []Code {
+	r.mu.RLock()
+	codes := make([]Code, 0, len(r.codes))
+	for code := range r.codes {
+		codes = append(codes, code)
+	}
+	r.mu.RUnlock()
+
+	sortCodes(codes)
+	return codes
+}
+
+// byCategory returns the codes registered under a category or alias in ascending order
+func (r *registry) byCategory(category Category) []Code {
+	r.mu.RLock()
+	name, ok := r.canonicalCategory(category)
+	if !ok {
+		r.mu.RUnlock()
+		return nil
+	}
+	var codes []Code
+	for code, info := range r.codes {
+		if info.Category == name {
+			codes = append(codes, code)
+		}
+	}
+	r.mu.RUnlock()
+
+	sortCodes(codes)
+	return codes
+}
+
+// categoryRange returns the reserved range of a category or alias
+func (r *registry) categoryRange(category Category) (min, max int, ok bool) {
+	info, ok := r.category(category)
+	return info.Min, info.Max, ok
+}
+
+// category returns the details of a category or alias
+func (r *registry) category(category Category) (CategoryInfo, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	name, ok := r.canonicalCategory(category)
+	if !ok {
+		return CategoryInfo{}, false
+	}
+	rg, ok := r.rangeOf(name)
+	if !ok {
+		return CategoryInfo{}, false
+	}
+	return rg.info(), true
+}
+
+// categories returns every reserved category ordered by range
+func (r *registry) categories() []CategoryInfo {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	infos := make([]CategoryInfo, 0, len(r.ranges))
+	for _, rg := range r.ranges {
+		infos = append(infos, rg.info())
+	}
+	return infos
+}
+
+// categoryOf returns the category of a registered code, or of the range containing it
+func (r *registry) categoryOf(code Code) (Category, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if info, ok := r.codes[code]; ok {
+		return info.Category, true
+	}
+	return r.categoryForNumber(code)
+}
+
+// baseCode returns the code at the start of a category's range if it is registered
+// (e.g. ErrCodeValidation for CategoryValidation)
+func (r *registry) baseCode(category Category) (Code, bool) {
+	min, _, ok := r.categoryRange(category)
+	if !ok {
+		return "", false
+	}
+	code := Code(strconv.Itoa(min))
+	if _, ok := r.lookup(code); !ok {
+		return "", false
+	}
+	return code, true
+}
+
+// sortCodes orders codes numerically, falling back to string order for non-numeric codes
+func sortCodes(codes []Code) {
+	sort.Slice(codes, func(i, j int) bool {
+		a, aok := parseCodeNumber(codes[i])
+		b, bok := parseCodeNumber(codes[j])
+		if aok && bok {
+			return a < b
+		}
+		return codes[i] < codes[j]
+	})
+}
+
+// =============================================================================
+// PUBLIC REGISTRY FUNCTIONS
+// =============================================================================
+
+// ReserveRange reserves an inclusive code range for a new category (e.g. "payments", 5000, 5099)
+// Aliases are alternative names accepted wherever the category name is expected
+// Returns an error if the category or an alias already exists or the range overlaps another category
+func ReserveRange(category Category, min, max int, aliases ...string) error {
+	return codeRegistry.reserveRange(category, min, max, aliases...)
+}
+
+// ReserveCategory reserves a code range like ReserveRange and sets the severity, HTTP status
+// and retryability applied to codes of the category that do not set their own
+func ReserveCategory(category Category, min, max int, defaults CategoryDefaults, aliases ...string) error {
+	return codeRegistry.reserveCategory(category, min, max, defaults, aliases...)
+}
+
+// Register adds an application-specific error code to a category
+// The category must have a reserved range containing the code, and the code must not already exist
+func Register(code Code, category Category, description string, opts CodeOptions) error {
+	return codeRegistry.register(code, category, description, opts)
+}
+
+// LookupCode returns the registration details of an error code
+func LookupCode(code Code) (CodeInfo, bool) {
+	return codeRegistry.lookup(code)
+}
+
+// CategoryRange returns the reserved code range of a category or alias
+func CategoryRange(category Category) (min, max int, ok bool) {
+	return codeRegistry.categoryRange(category)
+}
@@ -0,0 +1,212 @@
+package errors
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestRegistry creates an isolated registry with a single reserved range
+func newTestRegistry(t *testing.T) *registry {
+	r := newRegistry()
+	require.NoError(t, r.reserveRange("payments", 5000, 5099, "pay"))
+	return r
+}
+
+// TestBuiltinRegistry tests that every built-in code is registered under its range category
+func TestBuiltinRegistry(t *testing.T) {
+	for code := range CodeDetails {
+		info, ok := LookupCode(code)
+		require.True(t, ok, "code %s should be registered", code)
+		assert.Equal(t, CodeDetails[code], info.Description)
+
+		min, max, ok := CategoryRange(info.Category)
+		require.True(t, ok)
+		n, _ := parseCodeNumber(code)
+		assert.True(t, n >= min && n <= max, "code %s should be inside %s range", code, info.Category)
+	}
+}
+
+// TestRegistryRegister tests code registration and validation rules
+func TestRegistryRegister(t *testing.T) {
+	tests := []struct {
+		name         string
+		code         Code
+		category     Category
+		expectedCode Code // Expected code of the returned error, empty for success
+	}{
+		{
+			name:     "code inside reserved range",
+			code:     Code("5001"),
+			category: "payments",
+		},
+		{
+			name:     "category alias",
+			code:     Code("5002"),
+			category: "PAY",
+		},
+		{
+			name:         "code outside reserved range",
+			code:         Code("5100"),
+			category:     "payments",
+			expectedCode: ErrCodeInvalidInput,
+		},
+		{
+			name:         "unknown category",
+			code:         Code("5003"),
+			category:     "shipping",
+			expectedCode: ErrCodeInvalidInput,
+		},
+		{
+			name:         "non numeric code",
+			code:         Code("abc"),
+			category:     "payments",
+			expectedCode: ErrCodeInvalidFormat,
+		},
+	}
+
+	r := newTestRegistry(t)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := r.register(tt.code, tt.category, "test description", CodeOptions{Name: "ErrCodeTest"})
+			if tt.expectedCode == "" {
+				require.NoError(t, err)
+				info, ok := r.lookup(tt.code)
+				require.True(t, ok)
+				assert.Equal(t, Category("payments"), info.Category)
+				assert.Equal(t, "ErrCodeTest", info.Name)
+				return
+			}
+
+			require.Error(t, err)
+			assert.Equal(t, tt.expectedCode, err.(*Err).Code())
+			_, ok := r.lookup(tt.code)
+			assert.False(t, ok)
+		})
+	}
+}
+
+// TestRegistryRejectsDuplicates tests that a code cannot be registered twice
+func TestRegistryRejectsDuplicates(t *testing.T) {
+	r := newTestRegistry(t)
+	require.NoError(t, r.register("5000", "payments", "first", CodeOptions{}))
+
+	err := r.register("5000", "payments", "second", CodeOptions{})
+	require.Error(t, err)
+	assert.Equal(t, ErrCodeConflict, err.(*Err).Code())
+
+	info, _ := r.lookup("5000")
+	assert.Equal(t, "first", info.Description)
+}
+
+// TestReserveRange tests range reservation and overlap detection
+func TestReserveRange(t *testing.T) {
+	tests := []struct {
+		name     string
+		category Category
+		min      int
+		max      int
+		aliases  []string
+		wantErr  bool
+	}{
+		{name: "non overlapping range", category: "shipping", min: 5100, max: 5199},
+		{name: "overlapping range", category: "billing", min: 5050, max: 5150, wantErr: true},
+		{name: "duplicate category", category: "payments", min: 6000, max: 6099, wantErr: true},
+		{name: "duplicate alias", category: "refunds", min: 6100, max: 6199, aliases: []string{"pay"}, wantErr: true},
+		{name: "inverted range", category: "orders", min: 7099, max: 7000, wantErr: true},
+		{name: "empty category", category: "", min: 8000, max: 8099, wantErr: true},
+	}
+
+	r := newTestRegistry(t)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := r.reserveRange(tt.category, tt.min, tt.max, tt.aliases...)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+// TestRegistryCategoryDefaults tests that codes inherit the defaults of their category
+func TestRegistryCategoryDefaults(t *testing.T) {
+	r := newRegistry()
+	defaults := CategoryDefaults{Severity: SeverityWarn, HTTPStatus: 402, Retryable: true}
+	require.NoError(t, r.reserveCategory("billing", 6000, 6099, defaults))
+
+	require.NoError(t, r.register("6000", "billing", "inherits", CodeOptions{}))
+	require.NoError(t, r.register("6001", "billing", "overrides", CodeOptions{HTTPStatus: 409}))
+	require.NoError(t, r.register("6002", "billing", "opts out", CodeOptions{Retryable: Bool(false)}))
+
+	inherited, _ := r.lookup("6000")
+	assert.Equal(t, 402, inherited.HTTPStatus)
+	assert.True(t, inherited.Retryable)
+	assert.Equal(t, SeverityWarn, inherited.Severity)
+
+	overridden, _ := r.lookup("6001")
+	assert.Equal(t, 409, overridden.HTTPStatus)
+	assert.True(t, overridden.Retryable, "codes without their own retryability inherit the category's")
+
+	optedOut, _ := r.lookup("6002")
+	assert.False(t, optedOut.Retryable, "an explicit false overrides a retryable category")
+
+	// Categories without defaults fall back to 500 and SeverityError
+	r2 := newTestRegistry(t)
+	require.NoError(t, r2.register("5000", "payments", "plain", CodeOptions{}))
+	plain, _ := r2.lookup("5000")
+	assert.Equal(t, 500, plain.HTTPStatus)
+	assert.Equal(t, SeverityError, plain.Severity)
+
+	assert.Error(t, r.reserveCategory("bad-status", 7000, 7099, CategoryDefaults{HTTPStatus: 42}))
+	assert.Error(t, r.reserveCategory("bad-severity", 7100, 7199, CategoryDefaults{Severity: "loud"}))
+}
+
+// TestRegistryByCategory tests that category listings are derived from registrations
+func TestRegistryByCategory(t *testing.T) {
+	r := newTestRegistry(t)
+	require.NoError(t, r.register("5010", "payments", "b", CodeOptions{}))
+	require.NoError(t, r.register("5002", "payments", "a", CodeOptions{}))
+
+	assert.Equal(t, []Code{"5002", "5010"}, r.byCategory("payments"))
+	assert.Equal(t, []Code{"5002", "5010"}, r.byCategory("pay"))
+	assert.Empty(t, r.byCategory("unknown"))
+}
+
+// TestRegisterGlobal tests that application codes become visible through the package functions
+func TestRegisterGlobal(t *testing.T) {
+	require.NoError(t, ReserveRange("registry-test", 9900, 9909))
+	require.NoError(t, Register("9901", "registry-test", "Registry test failure", CodeOptions{}))
+
+	assert.True(t, IsValidCode("9901"))
+	assert.Equal(t, "Registry test failure", GetCodeDescription("9901"))
+	assert.Equal(t, []Code{"9901"}, GetCodesByCategory("registry-test"))
+	assert.Contains(t, GetAllCodes(), Code("9901"))
+}
+
+// TestRegistryConcurrentAccess tests that reads are safe while codes are being registered
+func TestRegistryConcurrentAccess(t *testing.T) {
+	r := newTestRegistry(t)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			_ = r.register(Code(fmt.Sprintf("%d", 5000+i)), "payments", "concurrent", CodeOptions{})
+		}(i)
+		go func() {
+			defer wg.Done()
+			_ = r.byCategory("payments")
+			_, _ = r.lookup("5000")
+			_ = r.all()
+		}()
+	}
+	wg.Wait()
+
+	assert.Len(t, r.byCategory("payments"), 50)
+}
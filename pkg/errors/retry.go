@@ -0,0 +1,56 @@
+package errors
+
+import (
+	"context"
+	stderrors "errors"
+	"net"
+)
+
+// IsRetryable reports whether an error describes a transient failure worth retrying
+// The chain is walked from the outermost error inwards and the first decisive error wins:
+//   - an Err with an explicit WithRetryable override
+//   - an Err whose code is registered as retryable
+//   - a net.Error reporting a timeout, or context.DeadlineExceeded
+//
+// Aggregated errors are retryable only when every member is
+func IsRetryable(err error) bool {
+	for err != nil {
+		switch e := err.(type) {
+		case *Err:
+			if e.retryable != nil {
+				return *e.retryable
+			}
+			if info, ok := codeRegistry.lookup(e.code); ok && info.Retryable {
+				return true
+			}
+		case net.Error:
+			if e.Timeout() {
+				return true
+			}
+		}
+		if err == context.DeadlineExceeded {
+			return true
+		}
+
+		switch u := err.(type) {
+		case interface{ Unwrap() []error }:
+			return allRetryable(u.Unwrap())
+		default:
+			err = stderrors.Unwrap(err)
+		}
+	}
+	return false
+}
+
+// allRetryable reports whether a non-empty set of errors is retryable as a whole
+func allRetryable(errs []error) bool {
+	if len(errs) == 0 {
+		return false
+	}
+	for _, err := range errs {
+		if !IsRetryable(err) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,109 @@
+package errors
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// timeoutError is a net.Error used to simulate network failures
+type timeoutError struct {
+	timeout bool
+}
+
+func (e timeoutError) Error() string   { return "i/o failure" }
+func (e timeoutError) Timeout() bool   { return e.timeout }
+func (e timeoutError) Temporary() bool { return false }
+
+var _ net.Error = timeoutError{}
+
+// TestIsRetryable tests retry classification across codes, overrides and wrapped chains
+func TestIsRetryable(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{name: "nil error", err: nil, expected: false},
+		{name: "plain error", err: stderrors.New("boom"), expected: false},
+		{name: "timeout code", err: NewErrDefault(ErrCodeTimeout, "timed out", "app"), expected: true},
+		{name: "network code", err: NewErrDefault(ErrCodeNetwork, "unreachable", "app"), expected: true},
+		{name: "db connection code", err: NewErrDefault(ErrCodeDBConnection, "refused", "app"), expected: true},
+		{name: "locked code", err: NewErrDefault(ErrCodeLocked, "locked", "app"), expected: true},
+		{name: "third party code", err: NewErrDefault(ErrCodeThirdParty, "unavailable", "app"), expected: true},
+		{name: "non retryable code", err: NewErrDefault(ErrCodeValidation, "invalid", "app"), expected: false},
+		{
+			name:     "override disables retry",
+			err:      NewErrDefault(ErrCodeTimeout, "timed out", "app").WithRetryable(false),
+			expected: false,
+		},
+		{
+			name:     "override enables retry",
+			err:      NewErrDefault(ErrCodeConflict, "version mismatch", "app").WithRetryable(true),
+			expected: true,
+		},
+		{
+			name:     "wrapped retryable code",
+			err:      fmt.Errorf("fetch: %w", NewErrDefault(ErrCodeNetwork, "unreachable", "app")),
+			expected: true,
+		},
+		{
+			name:     "outer override wins over inner code",
+			err:      NewErr(ErrCodeInternal, NewErrDefault(ErrCodeTimeout, "timed out", "app"), "failed", "app").WithRetryable(false),
+			expected: false,
+		},
+		{
+			name:     "net timeout inside coded error",
+			err:      NewErr(ErrCodeHTTP, timeoutError{timeout: true}, "request failed", "app"),
+			expected: true,
+		},
+		{name: "net error without timeout", err: timeoutError{timeout: false}, expected: false},
+		{name: "deadline exceeded", err: fmt.Errorf("query: %w", context.DeadlineExceeded), expected: true},
+		{name: "canceled context", err: context.Canceled, expected: false},
+		{
+			name: "all members retryable",
+			err: NewMultiErr("app").Append(
+				NewErrDefault(ErrCodeTimeout, "timed out", "app"),
+				NewErrDefault(ErrCodeLocked, "locked", "app"),
+			),
+			expected: true,
+		},
+		{
+			name: "one member not retryable",
+			err: NewMultiErr("app").Append(
+				NewErrDefault(ErrCodeTimeout, "timed out", "app"),
+				NewErrDefault(ErrCodeMissingField, "name is required", "app"),
+			),
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, IsRetryable(tt.err))
+		})
+	}
+}
+
+// TestRegisterRetryable tests that application codes can be registered as retryable
+func TestRegisterRetryable(t *testing.T) {
+	r := newRegistry()
+	require.NoError(t, r.reserveRange("queue", 5200, 5299))
+	require.NoError(t, r.register("5201", "queue", "Broker unavailable", CodeOptions{Retryable: Bool(true)}))
+
+	info, ok := r.lookup("5201")
+	require.True(t, ok)
+	assert.True(t, info.Retryable)
+
+	// Codes of a retryable category inherit it unless they opt out
+	require.NoError(t, ReserveCategory("retry-test", 9910, 9919, CategoryDefaults{Retryable: true}))
+	require.NoError(t, Register("9910", "retry-test", "Job timed out", CodeOptions{}))
+	require.NoError(t, Register("9911", "retry-test", "Job payload invalid", CodeOptions{Retryable: Bool(false)}))
+	assert.True(t, IsRetryable(NewErrDefault("9910", "timed out", "jobs")))
+	assert.False(t, IsRetryable(NewErrDefault("9911", "invalid payload", "jobs")))
+}
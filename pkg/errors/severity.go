@@ -0,0 +1,121 @@
+package errors
+
+import (
+	stderrors "errors"
+	"strings"
+)
+
+// Severity describes how serious an error is and drives the level it is logged at
+type Severity string
+
+// Supported severities, from least to most serious
+// The zero value means "not set" and resolves to SeverityError
+const (
+	SeverityDebug    Severity = "debug"    // Diagnostic noise, e.g. expected cache misses
+	SeverityInfo     Severity = "info"     // Expected outcomes such as a record not being found
+	SeverityWarn     Severity = "warn"     // Client mistakes and recoverable problems
+	SeverityError    Severity = "error"    // Failures that need attention
+	SeverityCritical Severity = "critical" // Failures that need immediate attention and alerting
+)
+
+// ParseSeverity converts a severity name (case-insensitive, "warning" accepted) to a Severity
+func ParseSeverity(s string) (Severity, bool) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "debug":
+		return SeverityDebug, true
+	case "info":
+		return SeverityInfo, true
+	case "warn", "warning":
+		return SeverityWarn, true
+	case "error":
+		return SeverityError, true
+	case "critical":
+		return SeverityCritical, true
+	}
+	return "", false
+}
+
+// String returns the severity name
+func (s Severity) String() string {
+	return string(s)
+}
+
+// rank orders severities from least to most serious; unset severities rank as SeverityError
+func (s Severity) rank() int {
+	switch s.orDefault() {
+	case SeverityDebug:
+		return 0
+	case SeverityInfo:
+		return 1
+	case SeverityWarn:
+		return 2
+	case SeverityCritical:
+		return 4
+	}
+	return 3
+}
+
+// orDefault returns the severity or SeverityError when it is not set
+func (s Severity) orDefault() Severity {
+	if s == "" {
+		return SeverityError
+	}
+	return s
+}
+
+// valid reports whether the severity is one of the supported constants
+func (s Severity) valid() bool {
+	switch s {
+	case SeverityDebug, SeverityInfo, SeverityWarn, SeverityError, SeverityCritical:
+		return true
+	}
+	return false
+}
+
+// SeverityOf returns how serious an error is
+// The outermost coded error decides: its WithSeverity override if set, otherwise the
+// severity of its code. Aggregated errors take the highest severity of their members.
+// Errors without a code are SeverityError; nil returns an empty severity
+func SeverityOf(err error) Severity {
+	if err == nil {
+		return ""
+	}
+	for err != nil {
+		switch e := err.(type) {
+		case *Err:
+			if e.severity != "" {
+				return e.severity
+			}
+			return codeSeverity(e.code)
+		case interface{ Unwrap() []error }:
+			return maxSeverity(e.Unwrap())
+		case Error:
+			return codeSeverity(e.Code())
+		}
+		err = stderrors.Unwrap(err)
+	}
+	return SeverityError
+}
+
+// codeSeverity returns the registered severity of a code, falling back to the
+// defaults of the category whose range contains it
+func codeSeverity(code Code) Severity {
+	if info, ok := codeRegistry.lookup(code); ok {
+		return info.Severity
+	}
+	if info, ok := codeRegistry.category(CategoryOf(code)); ok {
+		return info.Defaults.Severity.orDefault()
+	}
+	return SeverityError
+}
+
+// maxSeverity returns the highest severity of a set of errors, SeverityError if empty
+func maxSeverity(errs []error) Severity {
+	highest := Severity("")
+	for _, err := range errs {
+		if s := SeverityOf(err); s != "" && (highest == "" || s.rank() > highest.rank()) {
+			highest = s
+		}
+	}
+	return highest.orDefault()
+}
@@ -0,0 +1,90 @@
+package errors
+
+import (
+	stderrors "errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestParseSeverity tests parsing of severity names
+func TestParseSeverity(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Severity
+		ok       bool
+	}{
+		{input: "debug", expected: SeverityDebug, ok: true},
+		{input: "INFO", expected: SeverityInfo, ok: true},
+		{input: "warning", expected: SeverityWarn, ok: true},
+		{input: " error ", expected: SeverityError, ok: true},
+		{input: "critical", expected: SeverityCritical, ok: true},
+		{input: "fatal", ok: false},
+		{input: "", ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			severity, ok := ParseSeverity(tt.input)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.expected, severity)
+		})
+	}
+}
+
+// TestSeverityOf tests severity resolution from codes, categories and overrides
+func TestSeverityOf(t *testing.T) {
+	notFound := NewErrDefault(ErrCodeDBNotFound, "user not found", "users")
+
+	tests := []struct {
+		name     string
+		err      error
+		expected Severity
+	}{
+		{name: "nil error", err: nil, expected: ""},
+		{name: "plain error", err: stderrors.New("boom"), expected: SeverityError},
+		{name: "code severity", err: notFound, expected: SeverityInfo},
+		{name: "category severity", err: NewErrDefault(ErrCodeMissingField, "name is required", "app"), expected: SeverityWarn},
+		{name: "critical code", err: NewErrDefault(ErrCodeInitialization, "startup failed", "app"), expected: SeverityCritical},
+		{name: "unregistered code in a category", err: NewErrDefault(Code("1498"), "odd input", "app"), expected: SeverityWarn},
+		{
+			name:     "per-error override",
+			err:      NewErrDefault(ErrCodeDatabase, "cache warmup skipped", "app").WithSeverity(SeverityDebug),
+			expected: SeverityDebug,
+		},
+		{name: "wrapped with fmt", err: fmt.Errorf("lookup: %w", notFound), expected: SeverityInfo},
+		{
+			name:     "outermost coded error wins",
+			err:      NewErr(ErrCodeDatabase, notFound, "load failed", "app"),
+			expected: SeverityError,
+		},
+		{
+			name:     "multi error takes the highest member",
+			err:      NewMultiErr("app").Append(notFound, NewErrDefault(ErrCodeMissingField, "name is required", "app")),
+			expected: SeverityWarn,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, SeverityOf(tt.err))
+		})
+	}
+}
+
+// TestRegistrySeverity tests that registered code severities override the category default
+func TestRegistrySeverity(t *testing.T) {
+	r := newRegistry()
+	require.NoError(t, r.reserveCategory("billing", 6000, 6099, CategoryDefaults{Severity: SeverityWarn}))
+	require.NoError(t, r.register("6000", "billing", "inherits", CodeOptions{}))
+	require.NoError(t, r.register("6001", "billing", "overrides", CodeOptions{Severity: SeverityCritical}))
+
+	inherited, _ := r.lookup("6000")
+	assert.Equal(t, SeverityWarn, inherited.Severity)
+	overridden, _ := r.lookup("6001")
+	assert.Equal(t, SeverityCritical, overridden.Severity)
+
+	assert.Error(t, r.register("6002", "billing", "invalid", CodeOptions{Severity: "loud"}))
+}
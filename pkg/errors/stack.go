@@ -0,0 +1,63 @@
+package errors
+
+import (
+	"fmt"
+	"io"
+	"runtime"
+	"strings"
+)
+
+// maxStackDepth bounds the number of frames recorded when an error is created
+const maxStackDepth = 32
+
+// stack holds the program counters of the call stack where an error was created
+type stack []uintptr
+
+// callers records the stack of the caller, skipping the given number of extra frames
+// skip 0 starts at the function calling callers
+func callers(skip int) stack {
+	pcs := make([]uintptr, maxStackDepth)
+	n := runtime.Callers(skip+2, pcs)
+	return stack(pcs[:n])
+}
+
+// frames resolves the recorded program counters into frames, innermost first
+func (s stack) frames() []runtime.Frame {
+	if len(s) == 0 {
+		return nil
+	}
+	frames := make([]runtime.Frame, 0, len(s))
+	iter := runtime.CallersFrames(s)
+	for {
+		frame, more := iter.Next()
+		frames = append(frames, frame)
+		if !more {
+			break
+		}
+	}
+	return frames
+}
+
+// stackTracer is implemented by errors that carry the stack where they were created
+type stackTracer interface {
+	StackTrace() []runtime.Frame
+}
+
+// funcName strips the package path from a fully qualified function name
+// "github.com/org/repo/pkg.(*Type).Method" becomes "(*Type).Method"
+func funcName(name string) string {
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		name = name[i+1:]
+	}
+	if i := strings.Index(name, "."); i >= 0 {
+		name = name[i+1:]
+	}
+	return name
+}
+
+// writeStack writes one "function\n\tfile:line" entry per frame, as printed by %+v
+func writeStack(w io.Writer, frames []runtime.Frame) {
+	for _, frame := range frames {
+		fmt.Fprintf(w, "\n%s\n\t%s:%d", frame.Function, frame.File, frame.Line)
+	}
+}
@@ -0,0 +1,121 @@
+package errors
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// maxTrackedFingerprints bounds the memory used by a tracker
+// Occurrences of new fingerprints beyond the limit are counted as overflow
+const maxTrackedFingerprints = 10000
+
+// ErrorStats summarizes the occurrences of one error fingerprint
+type ErrorStats struct {
+	Fingerprint string    // Stable hash identifying the error, see Fingerprint
+	Code        Code      // Error code of the tracked error
+	Category    Category  // Category of the code, empty for codes outside every range
+	Template    string    // Normalized message template
+	Count       uint64    // Number of occurrences since tracking started
+	FirstSeen   time.Time // Time of the first occurrence
+	LastSeen    time.Time // Time of the most recent occurrence
+	Sample      error     // The first error seen with this fingerprint
+}
+
+// ErrorTracker counts error occurrences per fingerprint in process
+// It is safe for concurrent use
+type ErrorTracker struct {
+	mu       sync.Mutex
+	stats    map[string]*ErrorStats
+	overflow uint64
+	now      func() time.Time
+}
+
+// NewErrorTracker creates an empty tracker
+func NewErrorTracker() *ErrorTracker {
+	return &ErrorTracker{
+		stats: make(map[string]*ErrorStats),
+		now:   time.Now,
+	}
+}
+
+// Track records an occurrence of err and returns the updated statistics of its fingerprint
+// Nil errors are ignored and return empty statistics
+func (t *ErrorTracker) Track(err error) ErrorStats {
+	if err == nil {
+		return ErrorStats{}
+	}
+	fingerprint := Fingerprint(err)
+	now := t.now()
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	stats, ok := t.stats[fingerprint]
+	if !ok {
+		code, template := fingerprintParts(err)
+		stats = &ErrorStats{
+			Fingerprint: fingerprint,
+			Code:        code,
+			Category:    CategoryOf(code),
+			Template:    template,
+			FirstSeen:   now,
+			Sample:      err,
+		}
+		if len(t.stats) >= maxTrackedFingerprints {
+			t.overflow++
+			stats.Count, stats.LastSeen = 1, now
+			return *stats
+		}
+		t.stats[fingerprint] = stats
+	}
+	stats.Count++
+	stats.LastSeen = now
+	return *stats
+}
+
+// Snapshot returns the statistics of every tracked fingerprint, most frequent first
+func (t *ErrorTracker) Snapshot() []ErrorStats {
+	t.mu.Lock()
+	snapshot := make([]ErrorStats, 0, len(t.stats))
+	for _, stats := range t.stats {
+		snapshot = append(snapshot, *stats)
+	}
+	t.mu.Unlock()
+
+	sort.Slice(snapshot, func(i, j int) bool {
+		if snapshot[i].Count != snapshot[j].Count {
+			return snapshot[i].Count > snapshot[j].Count
+		}
+		return snapshot[i].Fingerprint < snapshot[j].Fingerprint
+	})
+	return snapshot
+}
+
+// CountByCategory returns the number of tracked occurrences per category
+// Occurrences of codes outside every category are counted under the empty category
+func (t *ErrorTracker) CountByCategory() map[Category]uint64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	counts := make(map[Category]uint64)
+	for _, stats := range t.stats {
+		counts[stats.Category] += stats.Count
+	}
+	return counts
+}
+
+// Overflow returns the number of occurrences that were not tracked because the
+// fingerprint limit was reached
+func (t *ErrorTracker) Overflow() uint64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.overflow
+}
+
+// Reset discards all tracked statistics
+func (t *ErrorTracker) Reset() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.stats = make(map[string]*ErrorStats)
+	t.overflow = 0
+}
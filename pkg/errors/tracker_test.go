@@ -0,0 +1,74 @@
+package errors
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestErrorTrackerTrack tests occurrence counting and timestamps
+func TestErrorTrackerTrack(t *testing.T) {
+	tracker := NewErrorTracker()
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	now := start
+	tracker.now = func() time.Time { return now }
+
+	sample := newLookupErr(1)
+	stats := tracker.Track(sample)
+	assert.Equal(t, uint64(1), stats.Count)
+
+	now = start.Add(time.Minute)
+	stats = tracker.Track(newLookupErr(2))
+	assert.Equal(t, uint64(2), stats.Count)
+	assert.Equal(t, start, stats.FirstSeen)
+	assert.Equal(t, now, stats.LastSeen)
+	assert.Same(t, sample, stats.Sample, "the first occurrence is kept as sample")
+	assert.Equal(t, ErrCodeDBNotFound, stats.Code)
+	assert.Equal(t, CategoryDatabase, stats.Category)
+	assert.Equal(t, "user <num> not found", stats.Template)
+
+	assert.Equal(t, ErrorStats{}, tracker.Track(nil))
+}
+
+// TestErrorTrackerSnapshot tests ordering and reset of the snapshot
+func TestErrorTrackerSnapshot(t *testing.T) {
+	tracker := NewErrorTracker()
+	for i := 0; i < 3; i++ {
+		tracker.Track(newLookupErr(i))
+	}
+	tracker.Track(NewErrDefault(ErrCodeTimeout, "request timed out", "app"))
+
+	snapshot := tracker.Snapshot()
+	require.Len(t, snapshot, 2)
+	assert.Equal(t, uint64(3), snapshot[0].Count)
+	assert.Equal(t, ErrCodeTimeout, snapshot[1].Code)
+	assert.Equal(t, map[Category]uint64{CategoryDatabase: 3, CategoryHTTP: 1}, tracker.CountByCategory())
+
+	tracker.Reset()
+	assert.Empty(t, tracker.Snapshot())
+}
+
+// TestErrorTrackerConcurrent tests that tracking is safe from many goroutines
+func TestErrorTrackerConcurrent(t *testing.T) {
+	tracker := NewErrorTracker()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				tracker.Track(newLookupErr(i*10 + j))
+				_ = tracker.Snapshot()
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	snapshot := tracker.Snapshot()
+	require.Len(t, snapshot, 1)
+	assert.Equal(t, uint64(200), snapshot[0].Count)
+}
@@ -0,0 +1,151 @@
+package errors
+
+import (
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"reflect"
+)
+
+// WireError is the stable, transport-neutral form of an error for crossing service
+// boundaries, e.g. as gRPC status details or in dead-letter queue payloads
+// It only uses strings, string maps and lists so it maps directly onto a protobuf message
+type WireError struct {
+	Code      string            `json:"code"`
+	Message   string            `json:"message,omitempty"`
+	App       string            `json:"app,omitempty"`
+	Text      string            `json:"error,omitempty"` // Error() text of the error
+	Details   map[string]string `json:"details,omitempty"`
+	Retryable *bool             `json:"retryable,omitempty"` // Per-error retry override, if any
+	Severity  string            `json:"severity,omitempty"`  // Per-error severity override, if any
+	Causes    []WireCause       `json:"causes,omitempty"`    // Underlying errors, outermost first
+}
+
+// WireCause is one link of the cause chain of a WireError
+// Code, Message and App are only set for links that were coded errors
+type WireCause struct {
+	Code    string `json:"code,omitempty"`
+	Message string `json:"message,omitempty"`
+	App     string `json:"app,omitempty"`
+	Text    string `json:"error"`
+}
+
+// remoteError stands in for an uncoded error rebuilt from its wire form
+// It keeps the original text and the rest of the chain
+type remoteError struct {
+	text string
+	next error
+}
+
+func (e *remoteError) Error() string { return e.text }
+func (e *remoteError) Unwrap() error { return e.next }
+
+// isNilError reports whether err is nil, including typed nil pointers such as a nil *Err
+// held in an error interface, whose methods would panic
+func isNilError(err error) bool {
+	if err == nil {
+		return true
+	}
+	value := reflect.ValueOf(err)
+	return value.Kind() == reflect.Ptr && value.IsNil()
+}
+
+// CodeOf returns the code of the outermost coded error in the chain
+// Returns ErrCodeUnknown for errors without a code and an empty code for nil,
+// including a nil *Err held in an error interface
+func CodeOf(err error) Code {
+	if isNilError(err) {
+		return ""
+	}
+	var coded Error
+	if stderrors.As(err, &coded) && !isNilError(coded) {
+		return coded.Code()
+	}
+	return ErrCodeUnknown
+}
+
+// ToWire converts an error into its wire form
+// The code is taken from the outermost coded error in the chain, so uncoded errors
+// become ErrCodeUnknown and wrapped coded errors keep their code; nil, including a nil
+// *Err held in an error interface, returns nil
+func ToWire(err error) *WireError {
+	if isNilError(err) {
+		return nil
+	}
+
+	w := &WireError{Code: string(CodeOf(err)), Text: err.Error()}
+	chain := err
+	if e, ok := err.(*Err); ok && e != nil {
+		w.Message, w.App, w.Retryable = e.message, e.app, e.retryable
+		w.Severity = string(e.severity)
+		if len(e.details) > 0 {
+			w.Details = make(map[string]string, len(e.details))
+			for k, v := range e.details {
+				w.Details[k] = fmt.Sprint(v)
+			}
+		}
+		chain = e.er
+	} else if coded, ok := err.(Error); ok {
+		w.Message = coded.Message()
+	}
+
+	for cause := chain; !isNilError(cause); cause = stderrors.Unwrap(cause) {
+		link := WireCause{Text: cause.Error()}
+		if coded, ok := cause.(*Err); ok {
+			link.Code, link.Message, link.App = string(coded.code), coded.message, coded.app
+		}
+		w.Causes = append(w.Causes, link)
+	}
+	return w
+}
+
+// FromWire rebuilds an error from its wire form
+// Codes, messages and texts of the whole chain are restored, so CodeOf, Error and
+// errors.As work on the result; detail values are restored as strings
+func FromWire(w *WireError) *Err {
+	if w == nil {
+		return nil
+	}
+
+	// Rebuild the chain from the innermost cause outwards
+	var next error
+	for i := len(w.Causes) - 1; i >= 0; i-- {
+		link := w.Causes[i]
+		if link.Code != "" {
+			next = &Err{code: Code(link.Code), message: link.Message, app: link.App, er: next}
+		} else {
+			next = &remoteError{text: link.Text, next: next}
+		}
+	}
+	if next == nil && w.Text != "" {
+		next = &remoteError{text: w.Text}
+	}
+
+	err := &Err{
+		code:      Code(w.Code),
+		message:   w.Message,
+		app:       w.App,
+		er:        next,
+		retryable: w.Retryable,
+		severity:  Severity(w.Severity),
+	}
+	for k, v := range w.Details {
+		err.WithDetail(k, v)
+	}
+	return err
+}
+
+// MarshalJSON encodes the error in its wire form
+func (err *Err) MarshalJSON() ([]byte, error) {
+	return json.Marshal(ToWire(err))
+}
+
+// UnmarshalJSON decodes an error from its wire form
+func (err *Err) UnmarshalJSON(data []byte) error {
+	var w WireError
+	if e := json.Unmarshal(data, &w); e != nil {
+		return e
+	}
+	*err = *FromWire(&w)
+	return nil
+}
@@ -0,0 +1,124 @@
+package errors
+
+import (
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCodeOf tests code extraction from error chains
+func TestCodeOf(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected Code
+	}{
+		{name: "nil error", err: nil, expected: ""},
+		{name: "nil *Err", err: (*Err)(nil), expected: ""},
+		{name: "nil Error interface", err: Error((*Err)(nil)), expected: ""},
+		{name: "wrapped nil *Err", err: fmt.Errorf("ctx: %w", (*Err)(nil)), expected: ErrCodeUnknown},
+		{name: "plain error", err: stderrors.New("boom"), expected: ErrCodeUnknown},
+		{name: "coded error", err: NewErrDefault(ErrCodeTimeout, "timed out", "app"), expected: ErrCodeTimeout},
+		{name: "wrapped coded error", err: fmt.Errorf("ctx: %w", NewErrDefault(ErrCodeLocked, "locked", "app")), expected: ErrCodeLocked},
+		{
+			name:     "outermost code wins",
+			err:      NewErr(ErrCodeExternal, NewErrDefault(ErrCodeTimeout, "timed out", "inner"), "call failed", "outer"),
+			expected: ErrCodeExternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, CodeOf(tt.err))
+		})
+	}
+}
+
+// TestWireRoundTrip tests that codes, messages and the cause chain survive the wire
+func TestWireRoundTrip(t *testing.T) {
+	root := stderrors.New("connection reset")
+	inner := NewErr(ErrCodeNetwork, fmt.Errorf("dial db: %w", root), "network failure", "db-client")
+	outer := NewErr(ErrCodeDatabase, inner, "failed to load user", "user-service").
+		WithDetail("user_id", 42).
+		WithRetryable(true).
+		WithSeverity(SeverityCritical)
+
+	rebuilt := FromWire(ToWire(outer))
+
+	assert.Equal(t, ErrCodeDatabase, CodeOf(rebuilt))
+	assert.Equal(t, "failed to load user", rebuilt.Message())
+	assert.Equal(t, "user-service", rebuilt.App())
+	assert.Equal(t, outer.Error(), rebuilt.Error())
+	assert.Equal(t, map[string]interface{}{"user_id": "42"}, rebuilt.Details())
+	assert.True(t, IsRetryable(rebuilt))
+	assert.Equal(t, SeverityCritical, SeverityOf(rebuilt))
+
+	var coded *Err
+	require.True(t, stderrors.As(rebuilt.Er(), &coded))
+	assert.Equal(t, ErrCodeNetwork, coded.Code())
+	assert.Equal(t, "db-client", coded.App())
+	assert.Equal(t, "dial db: connection reset", coded.Error())
+	assert.Equal(t, outer.Cause().Error(), rebuilt.Cause().Error())
+}
+
+// TestToWire tests the wire form of uncoded and wrapped errors
+func TestToWire(t *testing.T) {
+	assert.Nil(t, ToWire(nil))
+	assert.Nil(t, FromWire(nil))
+	var nilErr *Err
+	assert.NotPanics(t, func() { assert.Nil(t, ToWire(nilErr)) }, "a nil *Err is no error")
+	assert.Nil(t, ToWire(Error(nilErr)))
+	wrappedNil := ToWire(fmt.Errorf("ctx: %w", nilErr))
+	assert.Equal(t, string(ErrCodeUnknown), wrappedNil.Code)
+	assert.Len(t, wrappedNil.Causes, 1, "the chain stops at the nil *Err")
+
+	plain := ToWire(stderrors.New("boom"))
+	assert.Equal(t, string(ErrCodeUnknown), plain.Code)
+	assert.Equal(t, "boom", FromWire(plain).Error())
+
+	wrapped := fmt.Errorf("handler: %w", NewErrDefault(ErrCodeNotFound, "order missing", "orders"))
+	w := ToWire(wrapped)
+	assert.Equal(t, string(ErrCodeNotFound), w.Code)
+	assert.Equal(t, "handler: order missing", w.Text)
+	assert.Equal(t, wrapped.Error(), FromWire(w).Error())
+	assert.Equal(t, ErrCodeNotFound, CodeOf(FromWire(w)))
+
+	noCause := ToWire(NewErr(ErrCodeInternal, nil, "internal", "app"))
+	assert.Empty(t, noCause.Causes)
+	assert.Nil(t, FromWire(noCause).Er())
+}
+
+// TestErrJSON tests the JSON encoding of Err
+func TestErrJSON(t *testing.T) {
+	err := NewErr(ErrCodeDBNotFound, stderrors.New("no rows"), "user not found", "users").WithDetail("id", "u-1")
+
+	data, e := json.Marshal(err)
+	require.NoError(t, e)
+	assert.JSONEq(t, `{
+		"code": "1204",
+		"message": "user not found",
+		"app": "users",
+		"error": "no rows",
+		"details": {"id": "u-1"},
+		"causes": [{"error": "no rows"}]
+	}`, string(data))
+
+	var decoded Err
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, ErrCodeDBNotFound, decoded.Code())
+	assert.Equal(t, "user not found", decoded.Message())
+	assert.Equal(t, "no rows", decoded.Error())
+
+	// Errors embedded in other payloads keep their code
+	var payload struct {
+		Err *Err `json:"err"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(`{"err": `+string(data)+`}`), &payload))
+	assert.Equal(t, ErrCodeDBNotFound, CodeOf(payload.Err))
+
+	assert.Error(t, json.Unmarshal([]byte(`{"code": 5}`), &decoded))
+}
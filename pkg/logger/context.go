@@ -0,0 +1,190 @@
+package logger
+
+import (
+	"context"
+
+	"github.com/BhaveshKaushal/base-lib/pkg/errors"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// =============================================================================
+// CONTEXT KEYS
+// =============================================================================
+
+// Field names of the request metadata carried in a context
+// Set them with the With*ID helpers or ContextWith to have them on every *Ctx log entry
+const (
+	RequestIDKey     = "request_id"     // Identifier of the incoming request
+	CorrelationIDKey = "correlation_id" // Identifier shared by all requests of one business transaction
+	TraceIDKey       = "trace_id"       // Distributed tracing identifier
+	UserIDKey        = "user_id"        // Identifier of the authenticated user
+)
+
+// contextKey is the private type of the context key so other packages cannot collide with it
+type contextKey struct{}
+
+// loggerContextKey is the private type of the context key for a Logger
+type loggerContextKey struct{}
+
+// fieldsKey is the context key under which log fields are stored
+var fieldsKey = contextKey{}
+
+// loggerKey is the context key under which a Logger is stored
+var loggerKey = loggerContextKey{}
+
+// =============================================================================
+// PUBLIC CONTEXT FUNCTIONS
+// =============================================================================
+
+// ContextWith returns a copy of ctx carrying the given log fields in addition to
+// any fields already stored in it; new values replace existing ones with the same key
+// The fields are copied, so later changes to the map do not affect the context
+func ContextWith(ctx context.Context, fields Fields) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	existing := FieldsFromContext(ctx)
+	merged := make(Fields, len(existing)+len(fields))
+	for k, v := range existing {
+		merged[k] = v
+	}
+	for k, v := range fields {
+		merged[k] = v
+	}
+	return context.WithValue(ctx, fieldsKey, merged)
+}
+
+// WithRequestID returns a copy of ctx carrying the request ID as the "request_id" field
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return ContextWith(ctx, Fields{RequestIDKey: id})
+}
+
+// WithCorrelationID returns a copy of ctx carrying the correlation ID as the "correlation_id" field
+func WithCorrelationID(ctx context.Context, id string) context.Context {
+	return ContextWith(ctx, Fields{CorrelationIDKey: id})
+}
+
+// WithTraceID returns a copy of ctx carrying the trace ID as the "trace_id" field
+func WithTraceID(ctx context.Context, id string) context.Context {
+	return ContextWith(ctx, Fields{TraceIDKey: id})
+}
+
+// WithUserID returns a copy of ctx carrying the user ID as the "user_id" field
+func WithUserID(ctx context.Context, id string) context.Context {
+	return ContextWith(ctx, Fields{UserIDKey: id})
+}
+
+// FieldsFromContext returns a copy of the log fields stored in ctx, nil if there are none
+func FieldsFromContext(ctx context.Context) Fields {
+	if ctx == nil {
+		return nil
+	}
+	stored, _ := ctx.Value(fieldsKey).(Fields)
+	if len(stored) == 0 {
+		return nil
+	}
+	fields := make(Fields, len(stored))
+	for k, v := range stored {
+		fields[k] = v
+	}
+	return fields
+}
+
+// ContextWithLogger returns a copy of ctx carrying a logger for the *Ctx functions
+// Use it to scope a component's or a test's entries to its own logger, see WithCore
+func ContextWithLogger(ctx context.Context, l Logger) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return context.WithValue(ctx, loggerKey, l)
+}
+
+// LoggerFromContext returns the logger stored in ctx, the default logger if there is none
+func LoggerFromContext(ctx context.Context) Logger {
+	if ctx == nil {
+		return defaultLogger
+	}
+	if l, ok := ctx.Value(loggerKey).(Logger); ok {
+		return l
+	}
+	return defaultLogger
+}
+
+// WithContext returns a zap logger carrying the fields stored in ctx and the trace_id,
+// span_id and trace_flags of its OpenTelemetry span, writing through the logger stored
+// in ctx if there is one
+// Returns the logger unchanged when ctx has no fields and nil if the logger is not initialized
+func WithContext(ctx context.Context) *zap.Logger {
+	logger := LoggerFromContext(ctx).Zap()
+	if logger == nil {
+		return nil
+	}
+	fields := withContextFields(ctx, nil)
+	if len(fields) == 0 {
+		return logger
+	}
+	return logger.With(fieldsToZapFields(fields)...)
+}
+
+// =============================================================================
+// CONTEXT-AWARE LOGGING FUNCTIONS
+// =============================================================================
+
+// DebugCtx logs a message at debug level with the fields and through the logger stored in ctx
+func DebugCtx(ctx context.Context, msg string, fields Fields) {
+	LoggerFromContext(ctx).log(zap.DebugLevel, msg, withContextFields(ctx, fields))
+}
+
+// InfoCtx logs a message at info level with the fields and through the logger stored in ctx
+func InfoCtx(ctx context.Context, msg string, fields Fields) {
+	LoggerFromContext(ctx).log(zap.InfoLevel, msg, withContextFields(ctx, fields))
+}
+
+// WarnCtx logs a message at warning level with the fields and through the logger stored in ctx
+func WarnCtx(ctx context.Context, msg string, fields Fields) {
+	LoggerFromContext(ctx).log(zap.WarnLevel, msg, withContextFields(ctx, fields))
+}
+
+// ErrorCtx logs an error at error level with the fields and through the logger stored in ctx
+// With SetSpanErrorRecording enabled the error is also recorded on the span in ctx,
+// if its entry was written and not filtered by level, sampling or the error throttle
+func ErrorCtx(ctx context.Context, msg string, err error, fields Fields) {
+	if LoggerFromContext(ctx).logError(zap.ErrorLevel, msg, err, withContextFields(ctx, fields)) {
+		recordSpanError(ctx, zapcore.ErrorLevel, msg, asCodedError(err))
+	}
+}
+
+// LogErrCtx logs an error at the level matching its severity with the fields and through the logger stored in ctx
+// With SetSpanErrorRecording enabled errors of error or critical severity are also recorded on the span in ctx,
+// if their entry was written and not filtered by level, sampling or the error throttle
+func LogErrCtx(ctx context.Context, msg string, err error, fields Fields) {
+	if isNilError(err) {
+		return
+	}
+	level := severityLevel(errors.SeverityOf(err))
+	if LoggerFromContext(ctx).logError(level, msg, err, withContextFields(ctx, fields)) {
+		recordSpanError(ctx, level, msg, asCodedError(err))
+	}
+}
+
+// withContextFields merges the fields stored in ctx and those of its OpenTelemetry span
+// with the call's fields into a new map
+// Fields passed to the call take precedence over span fields, which take precedence over context fields
+func withContextFields(ctx context.Context, fields Fields) Fields {
+	merged := FieldsFromContext(ctx)
+	span := spanFields(ctx)
+	if merged == nil && span == nil {
+		return fields
+	}
+	if merged == nil {
+		merged = make(Fields, len(span)+len(fields))
+	}
+	for k, v := range span {
+		merged[k] = v
+	}
+	for k, v := range fields {
+		merged[k] = v
+	}
+	return merged
+}
@@ -0,0 +1,123 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	errorcodes "github.com/BhaveshKaushal/base-lib/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// TestContextWith tests storing and merging fields in a context
+func TestContextWith(t *testing.T) {
+	assert.Nil(t, FieldsFromContext(context.Background()))
+
+	fields := Fields{"tenant": "acme"}
+	ctx := ContextWith(context.Background(), fields)
+	ctx = WithRequestID(ctx, "req-1")
+	ctx = WithCorrelationID(ctx, "corr-1")
+	ctx = WithTraceID(ctx, "trace-1")
+	ctx = WithUserID(ctx, "user-1")
+	fields["tenant"] = "changed"
+
+	assert.Equal(t, Fields{
+		"tenant":         "acme",
+		RequestIDKey:     "req-1",
+		CorrelationIDKey: "corr-1",
+		TraceIDKey:       "trace-1",
+		UserIDKey:        "user-1",
+	}, FieldsFromContext(ctx))
+
+	// Derived contexts do not change their parent
+	child := WithRequestID(ctx, "req-2")
+	assert.Equal(t, "req-2", FieldsFromContext(child)[RequestIDKey])
+	assert.Equal(t, "req-1", FieldsFromContext(ctx)[RequestIDKey])
+
+	// The returned map is a copy
+	FieldsFromContext(ctx)["tenant"] = "mutated"
+	assert.Equal(t, "acme", FieldsFromContext(ctx)["tenant"])
+}
+
+// TestContextLogging tests that context fields appear on every *Ctx entry
+func TestContextLogging(t *testing.T) {
+	var buf bytes.Buffer
+	core := zapcore.NewCore(
+		zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig()),
+		zapcore.AddSync(&buf),
+		zapcore.DebugLevel,
+	)
+
+	restoreLogger(t)
+	setRootLogger(zap.New(core))
+
+	ctx := WithUserID(WithRequestID(context.Background(), "req-42"), "user-7")
+	err := errorcodes.NewErrDefault(errorcodes.ErrCodeDBQuery, "query failed", "testapp")
+
+	tests := []struct {
+		name          string
+		log           func()
+		expectedLevel string
+	}{
+		{name: "debug", log: func() { DebugCtx(ctx, "debug message", nil) }, expectedLevel: "debug"},
+		{name: "info", log: func() { InfoCtx(ctx, "info message", Fields{"extra": 1}) }, expectedLevel: "info"},
+		{name: "warn", log: func() { WarnCtx(ctx, "warn message", nil) }, expectedLevel: "warn"},
+		{name: "error", log: func() { ErrorCtx(ctx, "error message", err, nil) }, expectedLevel: "error"},
+		{name: "log err", log: func() { LogErrCtx(ctx, "log err message", err, nil) }, expectedLevel: "error"},
+		{name: "context logger", log: func() { WithContext(ctx).Info("zap message") }, expectedLevel: "info"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf.Reset()
+			tt.log()
+
+			output := buf.String()
+			assert.Contains(t, output, `"level":"`+tt.expectedLevel+`"`)
+			assert.Contains(t, output, `"request_id":"req-42"`)
+			assert.Contains(t, output, `"user_id":"user-7"`)
+		})
+	}
+
+	// Call fields take precedence over context fields
+	buf.Reset()
+	InfoCtx(ctx, "override", Fields{RequestIDKey: "req-override"})
+	assert.Contains(t, buf.String(), `"request_id":"req-override"`)
+
+	// Contexts without fields log like the plain functions
+	buf.Reset()
+	InfoCtx(context.Background(), "plain", nil)
+	assert.NotContains(t, buf.String(), "request_id")
+	assert.Same(t, GetLogger(), WithContext(context.Background()))
+}
+
+// TestContextWithLogger tests that the *Ctx functions write through a logger stored in the context
+func TestContextWithLogger(t *testing.T) {
+	var root, scoped bytes.Buffer
+	newCore := func(buf *bytes.Buffer) zapcore.Core {
+		return zapcore.NewCore(zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig()), zapcore.AddSync(buf), zapcore.DebugLevel)
+	}
+
+	restoreLogger(t)
+	setRootLogger(zap.New(newCore(&root)))
+
+	assert.Equal(t, Default(), LoggerFromContext(context.Background()))
+
+	component := WithCore(newCore(&scoped)).Named("billing")
+	ctx := ContextWithLogger(WithRequestID(context.Background(), "req-9"), component)
+	assert.Equal(t, component, LoggerFromContext(ctx))
+
+	InfoCtx(ctx, "scoped message", nil)
+	WithContext(ctx).Warn("scoped zap message")
+	assert.Contains(t, scoped.String(), `"msg":"scoped message"`)
+	assert.Contains(t, scoped.String(), `"logger":"billing"`)
+	assert.Contains(t, scoped.String(), `"msg":"scoped zap message","request_id":"req-9"`)
+	assert.Empty(t, root.String(), "entries do not reach the root logger")
+
+	// Reconfiguring the root logger does not affect loggers with their own core
+	Initialize(LoggerConfig{AppName: "test-app", Options: &Options{Outputs: []OutputConfig{{Path: OutputStderr}}}})
+	component.Info("still scoped", nil)
+	assert.Contains(t, scoped.String(), "still scoped")
+}
@@ -0,0 +1,101 @@
+package logger
+
+import (
+	"time"
+
+	"github.com/BhaveshKaushal/base-lib/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// =============================================================================
+// TYPED FIELDS
+// =============================================================================
+
+// Field is a typed key-value pair for the *w logging functions, e.g. Infow
+// Unlike Fields, typed fields need no map and no reflection, so logging on hot
+// paths allocates little or nothing when the level is disabled
+type Field = zap.Field
+
+// Str returns a string field
+func Str(key, value string) Field {
+	return zap.String(key, value)
+}
+
+// Int returns an integer field
+func Int(key string, value int) Field {
+	return zap.Int(key, value)
+}
+
+// Int64 returns a 64-bit integer field
+func Int64(key string, value int64) Field {
+	return zap.Int64(key, value)
+}
+
+// Float64 returns a floating-point field
+func Float64(key string, value float64) Field {
+	return zap.Float64(key, value)
+}
+
+// Bool returns a boolean field
+func Bool(key string, value bool) Field {
+	return zap.Bool(key, value)
+}
+
+// Dur returns a duration field, written like "1.5s" by the console and in seconds by JSON
+func Dur(key string, value time.Duration) Field {
+	return zap.Duration(key, value)
+}
+
+// Time returns a timestamp field
+func Time(key string, value time.Time) Field {
+	return zap.Time(key, value)
+}
+
+// Err returns the "error" field with the error's message; a nil error adds no field
+func Err(err error) Field {
+	return zap.Error(err)
+}
+
+// Code returns the "code" field with an error code, as written by Error and LogErr
+func Code(code errors.Code) Field {
+	return zap.String("code", string(code))
+}
+
+// Any returns a field for a value of any type, converted like the values of Fields
+func Any(key string, value interface{}) Field {
+	return zap.Any(key, value)
+}
+
+// =============================================================================
+// TYPED LOGGING FUNCTIONS
+// =============================================================================
+
+// Debugw logs a message at debug level with typed fields, see Debug
+func Debugw(msg string, fields ...Field) {
+	defaultLogger.logw(zap.DebugLevel, msg, fields)
+}
+
+// Infow logs a message at info level with typed fields, see Info
+func Infow(msg string, fields ...Field) {
+	defaultLogger.logw(zap.InfoLevel, msg, fields)
+}
+
+// Warnw logs a message at warning level with typed fields, see Warn
+func Warnw(msg string, fields ...Field) {
+	defaultLogger.logw(zap.WarnLevel, msg, fields)
+}
+
+// Debugw logs a message at debug level with the bound and given typed fields
+func (l Logger) Debugw(msg string, fields ...Field) {
+	l.logw(zap.DebugLevel, msg, fields)
+}
+
+// Infow logs a message at info level with the bound and given typed fields
+func (l Logger) Infow(msg string, fields ...Field) {
+	l.logw(zap.InfoLevel, msg, fields)
+}
+
+// Warnw logs a message at warning level with the bound and given typed fields
+func (l Logger) Warnw(msg string, fields ...Field) {
+	l.logw(zap.WarnLevel, msg, fields)
+}
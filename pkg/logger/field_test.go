@@ -0,0 +1,110 @@
+package logger
+
+import (
+	"bytes"
+	stderrors "errors"
+	"testing"
+	"time"
+
+	errorcodes "github.com/BhaveshKaushal/base-lib/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// TestTypedFields tests the typed field constructors
+func TestTypedFields(t *testing.T) {
+	now := time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC)
+	tests := []struct {
+		name     string
+		field    Field
+		expected interface{}
+	}{
+		{name: "string", field: Str("user", "alice"), expected: "alice"},
+		{name: "int", field: Int("count", 3), expected: int64(3)},
+		{name: "int64", field: Int64("bytes", 1<<40), expected: int64(1 << 40)},
+		{name: "float", field: Float64("ratio", 0.5), expected: 0.5},
+		{name: "bool", field: Bool("cached", true), expected: true},
+		{name: "duration", field: Dur("elapsed", 1500*time.Millisecond), expected: 1500 * time.Millisecond},
+		{name: "time", field: Time("at", now), expected: now},
+		{name: "error", field: Err(stderrors.New("boom")), expected: "boom"},
+		{name: "code", field: Code(errorcodes.ErrCodeDBQuery), expected: "1202"},
+		{name: "any", field: Any("tags", []string{"a"}), expected: []interface{}{"a"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			enc := zapcore.NewMapObjectEncoder()
+			tt.field.AddTo(enc)
+			assert.Len(t, enc.Fields, 1)
+			for _, value := range enc.Fields {
+				assert.Equal(t, tt.expected, value)
+			}
+		})
+	}
+
+	enc := zapcore.NewMapObjectEncoder()
+	Err(nil).AddTo(enc)
+	assert.Empty(t, enc.Fields, "a nil error adds no field")
+}
+
+// TestTypedLogging tests the *w functions with bound and default fields
+func TestTypedLogging(t *testing.T) {
+	var buf bytes.Buffer
+	core := zapcore.NewCore(
+		zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig()),
+		zapcore.AddSync(&buf),
+		zapcore.DebugLevel,
+	)
+
+	restoreLogger(t)
+	setRootLogger(zap.New(core).With(zap.String("app_name", "test-app")))
+
+	tests := []struct {
+		name          string
+		log           func()
+		expectedLevel string
+		expected      []string
+	}{
+		{
+			name:          "debug",
+			log:           func() { Debugw("cache miss", Str("key", "user:1")) },
+			expectedLevel: "debug",
+			expected:      []string{`"key":"user:1"`},
+		},
+		{
+			name:          "info",
+			log:           func() { Infow("request handled", Int("status", 200), Dur("elapsed", time.Second)) },
+			expectedLevel: "info",
+			expected:      []string{`"status":200`, `"elapsed":1`},
+		},
+		{
+			name:          "warn",
+			log:           func() { Warnw("retrying", Err(stderrors.New("timeout")), Code(errorcodes.ErrCodeTimeout)) },
+			expectedLevel: "warn",
+			expected:      []string{`"error":"timeout"`, `"code":"` + string(errorcodes.ErrCodeTimeout) + `"`},
+		},
+		{
+			name: "instance",
+			log: func() {
+				Named("payments").With(Fields{"tenant": "acme"}).Infow("charged", Str("currency", "EUR"))
+			},
+			expectedLevel: "info",
+			expected:      []string{`"logger":"payments"`, `"tenant":"acme"`, `"currency":"EUR"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf.Reset()
+			tt.log()
+
+			output := buf.String()
+			assert.Contains(t, output, `"level":"`+tt.expectedLevel+`"`)
+			assert.Contains(t, output, `"app_name":"test-app"`, "default fields are bound to the logger")
+			for _, expected := range tt.expected {
+				assert.Contains(t, output, expected)
+			}
+		})
+	}
+}
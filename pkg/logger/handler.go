@@ -0,0 +1,128 @@
+package logger
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/BhaveshKaushal/base-lib/pkg/errors"
+)
+
+// =============================================================================
+// LEVEL HTTP HANDLER
+// =============================================================================
+
+// levelRequest is the body of a PUT or POST to LevelHandler
+// Level changes the logger level, Overrides maps logger names to levels ("" removes
+// an override) and TTL, e.g. "15m", makes all changes of the request temporary
+type levelRequest struct {
+	Level     string            `json:"level"`
+	Overrides map[string]string `json:"overrides"`
+	TTL       string            `json:"ttl"`
+}
+
+// LevelHandler returns an HTTP handler to inspect and change log levels at runtime
+//
+// GET responds with the LevelStatus as JSON. PUT and POST change levels and respond
+// with the new status; they accept a JSON body such as
+//
+//	{"level": "info", "overrides": {"payments": "debug"}, "ttl": "15m"}
+//
+// or the form or query parameters level=info, override=payments=debug (repeatable
+// or comma-separated, "payments=" removes the override) and ttl=15m
+// The handler performs no authentication; mount it on an internal admin port
+func LevelHandler() http.Handler {
+	return http.HandlerFunc(serveLevels)
+}
+
+// serveLevels implements LevelHandler
+func serveLevels(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPut, http.MethodPost:
+		req, err := decodeLevelRequest(r)
+		if err == nil {
+			err = applyLevelRequest(req)
+		}
+		if err != nil {
+			errors.WriteHTTPError(w, errors.NewErr(errors.ErrCodeInvalidInput, err, err.Error(), ""))
+			return
+		}
+	default:
+		w.Header().Set("Allow", "GET, PUT, POST")
+		http.Error(w, fmt.Sprintf("method %s is not allowed", r.Method), http.StatusMethodNotAllowed)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(Levels())
+}
+
+// decodeLevelRequest reads a level change from a JSON body or form and query parameters
+func decodeLevelRequest(r *http.Request) (levelRequest, error) {
+	var req levelRequest
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "application/json") {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			return req, fmt.Errorf("decoding request body: %w", err)
+		}
+		return req, nil
+	}
+
+	if err := r.ParseForm(); err != nil {
+		return req, fmt.Errorf("parsing request: %w", err)
+	}
+	req.Level = r.Form.Get("level")
+	req.TTL = r.Form.Get("ttl")
+	for _, value := range r.Form["override"] {
+		for _, pair := range strings.Split(value, ",") {
+			name, level, ok := strings.Cut(strings.TrimSpace(pair), "=")
+			if !ok {
+				return req, fmt.Errorf("override %q is not of the form name=level", pair)
+			}
+			if req.Overrides == nil {
+				req.Overrides = map[string]string{}
+			}
+			req.Overrides[strings.TrimSpace(name)] = strings.TrimSpace(level)
+		}
+	}
+	return req, nil
+}
+
+// applyLevelRequest validates a level change completely before applying any part of it
+func applyLevelRequest(req levelRequest) error {
+	var ttl time.Duration
+	if req.TTL != "" {
+		var err error
+		if ttl, err = time.ParseDuration(req.TTL); err != nil || ttl < 0 {
+			return fmt.Errorf("invalid ttl %q", req.TTL)
+		}
+	}
+	if req.Level == "" && len(req.Overrides) == 0 {
+		return fmt.Errorf("no level or overrides given")
+	}
+	if _, ok := lookupLevel(req.Level); req.Level != "" && !ok {
+		return fmt.Errorf("unknown log level %q", req.Level)
+	}
+	for name, level := range req.Overrides {
+		if name == "" {
+			return fmt.Errorf("logger name is empty")
+		}
+		if _, ok := lookupLevel(level); level != "" && !ok {
+			return fmt.Errorf("unknown log level %q for logger %q", level, name)
+		}
+	}
+
+	if req.Level != "" {
+		if err := SetLogLevelFor(req.Level, ttl); err != nil {
+			return err
+		}
+	}
+	for name, level := range req.Overrides {
+		if err := SetLoggerLevel(name, level, ttl); err != nil {
+			return err
+		}
+	}
+	return nil
+}
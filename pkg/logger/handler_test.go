@@ -0,0 +1,146 @@
+package logger
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestLevelHandler tests inspecting and changing levels over HTTP
+func TestLevelHandler(t *testing.T) {
+	resetLevels(t)
+	initializeForTest(t, LoggerConfig{AppName: "test-app", Environment: "test"})
+
+	handler := LevelHandler()
+	serve := func(method, target, contentType, body string) (*httptest.ResponseRecorder, LevelStatus) {
+		req := httptest.NewRequest(method, target, strings.NewReader(body))
+		if contentType != "" {
+			req.Header.Set("Content-Type", contentType)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		var status LevelStatus
+		if rec.Code == http.StatusOK {
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &status))
+		}
+		return rec, status
+	}
+
+	tests := []struct {
+		name           string
+		method         string
+		target         string
+		contentType    string
+		body           string
+		expectedCode   int
+		expectedLevel  string
+		expectedLevels map[string]string
+	}{
+		{
+			name:          "get reports the current level",
+			method:        http.MethodGet,
+			target:        "/log/level",
+			expectedCode:  http.StatusOK,
+			expectedLevel: "info",
+		},
+		{
+			name:           "put json body",
+			method:         http.MethodPut,
+			target:         "/log/level",
+			contentType:    "application/json",
+			body:           `{"level": "warn", "overrides": {"payments": "debug"}}`,
+			expectedCode:   http.StatusOK,
+			expectedLevel:  "warn",
+			expectedLevels: map[string]string{"payments": "debug"},
+		},
+		{
+			name:           "post form parameters",
+			method:         http.MethodPost,
+			target:         "/log/level",
+			contentType:    "application/x-www-form-urlencoded",
+			body:           url.Values{"override": {"orders=error,payments="}}.Encode(),
+			expectedCode:   http.StatusOK,
+			expectedLevel:  "warn",
+			expectedLevels: map[string]string{"orders": "error"},
+		},
+		{
+			name:           "put query parameters",
+			method:         http.MethodPut,
+			target:         "/log/level?level=debug&override=orders%3Dinfo",
+			expectedCode:   http.StatusOK,
+			expectedLevel:  "debug",
+			expectedLevels: map[string]string{"orders": "info"},
+		},
+		{
+			name:         "unknown level is rejected",
+			method:       http.MethodPut,
+			target:       "/log/level?level=debug&override=orders%3Dverbose",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "invalid ttl is rejected",
+			method:       http.MethodPut,
+			target:       "/log/level?level=debug&ttl=soon",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "empty change is rejected",
+			method:       http.MethodPost,
+			target:       "/log/level",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "malformed json is rejected",
+			method:       http.MethodPut,
+			target:       "/log/level",
+			contentType:  "application/json",
+			body:         `{"level":`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "other methods are not allowed",
+			method:       http.MethodDelete,
+			target:       "/log/level",
+			expectedCode: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec, status := serve(tt.method, tt.target, tt.contentType, tt.body)
+			assert.Equal(t, tt.expectedCode, rec.Code, rec.Body.String())
+			if tt.expectedCode != http.StatusOK {
+				return
+			}
+			assert.Equal(t, tt.expectedLevel, status.Level)
+			levels := map[string]string{}
+			for name, setting := range status.Overrides {
+				levels[name] = setting.Level
+			}
+			if tt.expectedLevels == nil {
+				tt.expectedLevels = map[string]string{}
+			}
+			assert.Equal(t, tt.expectedLevels, levels)
+		})
+	}
+
+	// Rejected requests change nothing
+	assert.Equal(t, "debug", Levels().Level)
+	assert.Equal(t, "info", Levels().Overrides["orders"].Level)
+
+	// A ttl makes every change of the request temporary
+	rec, status := serve(http.MethodPut, "/log/level?level=debug&override=payments%3Ddebug&ttl=1h", "", "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.NotNil(t, status.ExpiresAt)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), *status.ExpiresAt, time.Minute)
+	assert.NotNil(t, status.Overrides["payments"].ExpiresAt)
+	assert.Nil(t, status.Overrides["orders"].ExpiresAt)
+}
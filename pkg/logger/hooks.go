@@ -0,0 +1,335 @@
+package logger
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// =============================================================================
+// HOOKS
+// =============================================================================
+
+// Hook receives the log entries at or above its level, e.g. to forward them to an
+// external system. Hooks run on the logging goroutine after sampling and masking, so
+// Fire must return quickly; forwarders should queue entries like HTTPHook does
+// An error or panic from Fire is counted as a dropped entry and never reaches the caller
+type Hook interface {
+	Level() zapcore.Level       // Minimum level of the entries passed to Fire
+	Fire(entry HookEntry) error // Handles one entry; the entry may be kept
+}
+
+// HookEntry is a log entry passed to a hook
+type HookEntry struct {
+	Time       time.Time
+	Level      zapcore.Level
+	LoggerName string // Dotted component name, empty for the default logger
+	Message    string
+	Caller     string // File and line of the logging call, empty without caller annotation
+	Fields     Fields // All fields, including bound and default fields
+}
+
+// MarshalJSON writes the entry as one flat object like the JSON encoder does
+func (e HookEntry) MarshalJSON() ([]byte, error) {
+	object := make(map[string]interface{}, len(e.Fields)+5)
+	for k, v := range e.Fields {
+		object[k] = v
+	}
+	object["timestamp"] = e.Time.Format(time.RFC3339Nano)
+	object["level"] = e.Level.String()
+	object["msg"] = e.Message
+	if e.LoggerName != "" {
+		object["logger"] = e.LoggerName
+	}
+	if e.Caller != "" {
+		object["caller"] = e.Caller
+	}
+	return json.Marshal(object)
+}
+
+// FuncHook is a Hook calling a function, e.g. to count errors or update metrics
+type FuncHook struct {
+	level zapcore.Level
+	fn    func(HookEntry) error
+}
+
+// NewFuncHook returns a hook calling fn for every entry at or above level
+// fn runs on the logging goroutine and must not block
+func NewFuncHook(level zapcore.Level, fn func(HookEntry) error) *FuncHook {
+	return &FuncHook{level: level, fn: fn}
+}
+
+// Level returns the minimum level of the hook
+func (h *FuncHook) Level() zapcore.Level {
+	return h.level
+}
+
+// Fire calls the hook's function
+func (h *FuncHook) Fire(entry HookEntry) error {
+	return h.fn(entry)
+}
+
+// hookSet is an immutable list of registered hooks
+type hookSet struct {
+	hooks []*registeredHook
+	min   zapcore.Level // Lowest hook level, so Enabled needs no loop
+}
+
+// registeredHook is one AddHook registration; the pointer identifies it for removal
+type registeredHook struct {
+	Hook
+}
+
+// hooks holds the registered hooks; it is replaced as a whole on every change and
+// survives rebuilds of the root logger
+var hooks atomic.Pointer[hookSet]
+
+// hooksMu serializes AddHook and its removals
+var hooksMu sync.Mutex
+
+// AddHook registers a hook for the entries of all loggers and returns a function removing it
+// Hooks see entries after the logger level, sampling and masking, but regardless of output levels
+func AddHook(hook Hook) (remove func()) {
+	registration := &registeredHook{Hook: hook}
+	hooksMu.Lock()
+	defer hooksMu.Unlock()
+
+	var current []*registeredHook
+	if set := hooks.Load(); set != nil {
+		current = set.hooks
+	}
+	hooks.Store(newHookSet(append(current[:len(current):len(current)], registration)))
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			hooksMu.Lock()
+			defer hooksMu.Unlock()
+			set := hooks.Load()
+			remaining := make([]*registeredHook, 0, len(set.hooks))
+			for _, h := range set.hooks {
+				if h != registration {
+					remaining = append(remaining, h)
+				}
+			}
+			hooks.Store(newHookSet(remaining))
+		})
+	}
+}
+
+// newHookSet returns a set of the hooks with their lowest level
+func newHookSet(registered []*registeredHook) *hookSet {
+	set := &hookSet{hooks: registered, min: zapcore.InvalidLevel}
+	for _, h := range registered {
+		if level := h.Level(); level < set.min {
+			set.min = level
+		}
+	}
+	return set
+}
+
+// hookCore passes entries to the registered hooks; it is one of the outputs of the root logger
+type hookCore struct {
+	fields []zapcore.Field // Fields added with With
+}
+
+// Enabled reports whether any hook takes entries of the level
+func (c *hookCore) Enabled(level zapcore.Level) bool {
+	set := hooks.Load()
+	return set != nil && len(set.hooks) > 0 && level >= set.min
+}
+
+// With returns a core that adds the fields to every entry passed to the hooks
+func (c *hookCore) With(fields []zapcore.Field) zapcore.Core {
+	combined := make([]zapcore.Field, 0, len(c.fields)+len(fields))
+	combined = append(combined, c.fields...)
+	return &hookCore{fields: append(combined, fields...)}
+}
+
+// Check adds the core to entries any hook takes
+func (c *hookCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+// Write passes the entry to every hook whose level it meets
+func (c *hookCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	set := hooks.Load()
+	if set == nil {
+		return nil
+	}
+
+	enc := zapcore.NewMapObjectEncoder()
+	for _, field := range c.fields {
+		field.AddTo(enc)
+	}
+	for _, field := range fields {
+		field.AddTo(enc)
+	}
+	entry := HookEntry{
+		Time:       ent.Time,
+		Level:      ent.Level,
+		LoggerName: ent.LoggerName,
+		Message:    ent.Message,
+		Fields:     Fields(enc.Fields),
+	}
+	if ent.Caller.Defined {
+		entry.Caller = ent.Caller.TrimmedPath()
+	}
+
+	for _, hook := range set.hooks {
+		if ent.Level >= hook.Level() {
+			fireHook(hook, entry)
+		}
+	}
+	return nil // Hook failures are counted, never reported to the caller
+}
+
+// Sync does nothing; queued entries are delivered by the hooks themselves
+func (c *hookCore) Sync() error {
+	return nil
+}
+
+// fireHook calls a hook, counting an error or panic as a dropped entry
+func fireHook(hook Hook, entry HookEntry) {
+	defer func() {
+		if recover() != nil {
+			countHookDrops(entry.Level, 1)
+		}
+	}()
+	if err := hook.Fire(entry); err != nil {
+		countHookDrops(entry.Level, 1)
+	}
+}
+
+// countHookDrops records entries of a level that a hook did not deliver, see GetDropStats
+func countHookDrops(level zapcore.Level, n uint64) {
+	dropCounters.hooks[levelIndex(level)].Add(n)
+}
+
+// =============================================================================
+// HOOK QUEUE
+// =============================================================================
+
+// errQueueFull is returned by Fire when a queued hook cannot take more entries
+var errQueueFull = fmt.Errorf("hook queue is full")
+
+// hookQueue buffers entries for a hook and hands them to deliver in batches on its own goroutine
+// When the buffer is full new entries are dropped instead of blocking the logging goroutine
+type hookQueue struct {
+	entries   chan HookEntry
+	batchSize int
+	interval  time.Duration // Longest time an entry waits for its batch to fill
+	deliver   func([]HookEntry)
+	flush     chan chan struct{}
+	stop      chan struct{}
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// newHookQueue starts a queue; Close delivers the remaining entries and stops it
+func newHookQueue(size, batchSize int, interval time.Duration, deliver func([]HookEntry)) *hookQueue {
+	q := &hookQueue{
+		entries:   make(chan HookEntry, size),
+		batchSize: batchSize,
+		interval:  interval,
+		deliver:   deliver,
+		flush:     make(chan chan struct{}),
+		stop:      make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+	go q.run()
+	return q
+}
+
+// push queues an entry without blocking, returning errQueueFull if the buffer is full
+func (q *hookQueue) push(entry HookEntry) error {
+	select {
+	case <-q.stop:
+		return fmt.Errorf("hook is closed")
+	default:
+	}
+	select {
+	case q.entries <- entry:
+		return nil
+	default:
+		return errQueueFull
+	}
+}
+
+// Flush delivers the queued entries and waits until they are handled
+func (q *hookQueue) Flush() {
+	flushed := make(chan struct{})
+	select {
+	case q.flush <- flushed:
+		<-flushed
+	case <-q.done:
+	}
+}
+
+// Close delivers the queued entries and stops the queue; later entries are dropped
+func (q *hookQueue) Close() {
+	q.closeOnce.Do(func() {
+		close(q.stop)
+		<-q.done
+		for {
+			select {
+			case entry := <-q.entries: // Pushed while closing
+				countHookDrops(entry.Level, 1)
+			default:
+				return
+			}
+		}
+	})
+}
+
+// run collects entries into batches until the queue is closed
+func (q *hookQueue) run() {
+	defer close(q.done)
+	ticker := time.NewTicker(q.interval)
+	defer ticker.Stop()
+
+	batch := make([]HookEntry, 0, q.batchSize)
+	send := func() {
+		if len(batch) > 0 {
+			q.deliver(batch)
+			batch = make([]HookEntry, 0, q.batchSize) // deliver may keep the batch
+		}
+	}
+	drain := func() {
+		for {
+			select {
+			case entry := <-q.entries:
+				if batch = append(batch, entry); len(batch) >= q.batchSize {
+					send()
+				}
+			default:
+				send()
+				return
+			}
+		}
+	}
+
+	for {
+		select {
+		case entry := <-q.entries:
+			if batch = append(batch, entry); len(batch) >= q.batchSize {
+				send()
+			}
+		case <-ticker.C:
+			send()
+		case flushed := <-q.flush:
+			drain()
+			close(flushed)
+		case <-q.stop:
+			drain()
+			return
+		}
+	}
+}
@@ -0,0 +1,163 @@
+package logger
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// entryCollector collects the entries of a FuncHook
+type entryCollector struct {
+	mu      sync.Mutex
+	entries []HookEntry
+}
+
+// collect records an entry
+func (c *entryCollector) collect(entry HookEntry) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = append(c.entries, entry)
+	return nil
+}
+
+// all returns the collected entries
+func (c *entryCollector) all() []HookEntry {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]HookEntry(nil), c.entries...)
+}
+
+// TestHooks tests that hooks receive the entries at or above their level with all fields
+func TestHooks(t *testing.T) {
+	path := initializeForTest(t, LoggerConfig{AppName: "test-app", Environment: "test"})
+
+	var warnings, all entryCollector
+	removeWarnings := AddHook(NewFuncHook(zap.WarnLevel, warnings.collect))
+	defer removeWarnings()
+	removeAll := AddHook(NewFuncHook(zap.DebugLevel, all.collect))
+	defer removeAll()
+
+	Debug("below the logger level", nil)
+	Named("payments").With(Fields{"tenant": "acme"}).Info("payment accepted", Fields{"password": "hunter2"})
+	Warn("disk almost full", Fields{"free": 5})
+
+	require.Len(t, all.all(), 2, "the logger level applies to hooks")
+	accepted := all.all()[0]
+	assert.Equal(t, zap.InfoLevel, accepted.Level)
+	assert.Equal(t, "payment accepted", accepted.Message)
+	assert.Contains(t, accepted.Caller, "logger/hooks_test.go:", "the caller is the application's call site")
+	assert.NotEmpty(t, accepted.Caller)
+	assert.Equal(t, "test-app", accepted.Fields["app_name"], "default fields are included")
+	assert.Equal(t, "acme", accepted.Fields["tenant"])
+	assert.Equal(t, redacted, accepted.Fields["password"], "hooks see masked values")
+	assert.Equal(t, true, accepted.Fields[MaskedKey])
+
+	require.Len(t, warnings.all(), 1)
+	assert.Equal(t, "disk almost full", warnings.all()[0].Message)
+	assert.Contains(t, readLog(t, path), "disk almost full", "outputs still receive the entries")
+
+	// Hooks survive rebuilds of the logger and stop after removal
+	SetFormatter("console")
+	Warn("after rebuild", nil)
+	removeWarnings()
+	removeWarnings() // Removing twice is harmless
+	Warn("after removal", nil)
+	require.Len(t, warnings.all(), 2)
+	assert.Equal(t, "after rebuild", warnings.all()[1].Message)
+	assert.Len(t, all.all(), 4)
+}
+
+// TestHookFailures tests that failing and panicking hooks are counted and never reach the caller
+func TestHookFailures(t *testing.T) {
+	path := initializeForTest(t, LoggerConfig{AppName: "test-app", Environment: "test"})
+	before := GetDropStats()
+
+	var delivered entryCollector
+	defer AddHook(NewFuncHook(zap.InfoLevel, func(HookEntry) error { return fmt.Errorf("sink unavailable") }))()
+	defer AddHook(NewFuncHook(zap.ErrorLevel, func(HookEntry) error { panic("broken hook") }))()
+	defer AddHook(NewFuncHook(zap.InfoLevel, delivered.collect))()
+
+	assert.NotPanics(t, func() {
+		Info("first", nil)
+		Warn("second", nil)
+		LogErr("third", fmt.Errorf("boom"), nil)
+	})
+
+	assert.Len(t, delivered.all(), 3, "other hooks still run")
+	assert.Contains(t, readLog(t, path), "third")
+	delta := GetDropStats().since(before)
+	assert.Equal(t, map[string]uint64{"info": 1, "warn": 1, "error": 2}, delta.Hooks)
+	assert.Equal(t, uint64(4), delta.Total)
+}
+
+// TestHookEntryJSON tests the flat JSON form of hook entries
+func TestHookEntryJSON(t *testing.T) {
+	entry := HookEntry{
+		Time:       time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC),
+		Level:      zap.WarnLevel,
+		LoggerName: "jobs",
+		Message:    "job retried",
+		Caller:     "jobs/run.go:42",
+		Fields:     Fields{"attempt": 2},
+	}
+	raw, err := json.Marshal(entry)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"timestamp": "2024-01-02T15:04:05Z",
+		"level": "warn",
+		"logger": "jobs",
+		"msg": "job retried",
+		"caller": "jobs/run.go:42",
+		"attempt": 2
+	}`, string(raw))
+}
+
+// TestHookQueue tests batching, flushing and dropping when the queue is full
+func TestHookQueue(t *testing.T) {
+	before := GetDropStats()
+	release := make(chan struct{})
+	var mu sync.Mutex
+	var batches [][]HookEntry
+	q := newHookQueue(3, 3, time.Hour, func(batch []HookEntry) {
+		<-release
+		mu.Lock()
+		defer mu.Unlock()
+		batches = append(batches, batch)
+	})
+
+	entry := func(i int) HookEntry {
+		return HookEntry{Level: zapcore.InfoLevel, Message: fmt.Sprint(i)}
+	}
+
+	// The first three form a batch that blocks in deliver; three more fill the buffer
+	for i := 0; i < 3; i++ {
+		require.NoError(t, q.push(entry(i)))
+	}
+	assert.Eventually(t, func() bool { return len(q.entries) == 0 }, time.Second, time.Millisecond)
+	for i := 3; i < 6; i++ {
+		require.NoError(t, q.push(entry(i)))
+	}
+	assert.ErrorIs(t, q.push(entry(6)), errQueueFull, "a full queue does not block")
+
+	close(release)
+	q.Flush()
+	require.NoError(t, q.push(entry(7)))
+	q.Flush()
+	mu.Lock()
+	require.Len(t, batches, 3)
+	assert.Len(t, batches[0], 3)
+	assert.Len(t, batches[1], 3)
+	assert.Equal(t, []HookEntry{entry(7)}, batches[2], "flush delivers a partial batch")
+	mu.Unlock()
+
+	q.Close()
+	assert.Error(t, q.push(entry(6)), "a closed queue takes no entries")
+	assert.Zero(t, GetDropStats().since(before).Total, "the queue leaves counting to its hook")
+}
@@ -0,0 +1,153 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// =============================================================================
+// HTTP FORWARDING HOOK
+// =============================================================================
+
+// HTTPHookConfig configures an HTTPHook
+// Zero values are replaced by the defaults noted on each field
+type HTTPHookConfig struct {
+	URL           string            // Endpoint receiving batches as a JSON array of entries
+	Level         zapcore.Level     // Minimum level of forwarded entries
+	Headers       map[string]string // Extra request headers, e.g. an API key
+	Client        *http.Client      // Client for the requests; default has a 10s timeout
+	BufferSize    int               // Entries queued before new ones are dropped; default 10000
+	BatchSize     int               // Entries per request; default 100
+	FlushInterval time.Duration     // Longest time an entry waits for its batch to fill; default 1s
+	MaxRetries    int               // Retries of a failed batch before it is dropped; default 3, -1 disables retries
+	RetryBackoff  time.Duration     // Wait before the first retry, doubled for each further one; default 100ms
+}
+
+// HTTPHook forwards entries to an HTTP endpoint in batches on a background goroutine
+// Entries are queued without blocking the caller; when the queue is full, or a batch
+// still fails after the retries, entries are dropped and counted in GetDropStats
+type HTTPHook struct {
+	config HTTPHookConfig
+	queue  *hookQueue
+}
+
+// NewHTTPHook returns a started hook forwarding entries to config.URL
+// Register it with AddHook and Close it on shutdown to deliver the queued entries
+func NewHTTPHook(config HTTPHookConfig) (*HTTPHook, error) {
+	if config.URL == "" {
+		return nil, fmt.Errorf("http hook url is empty")
+	}
+	if config.Client == nil {
+		config.Client = &http.Client{Timeout: 10 * time.Second}
+	}
+	if config.BufferSize <= 0 {
+		config.BufferSize = 10000
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = 100
+	}
+	if config.FlushInterval <= 0 {
+		config.FlushInterval = time.Second
+	}
+	if config.MaxRetries == 0 {
+		config.MaxRetries = 3
+	}
+	if config.RetryBackoff <= 0 {
+		config.RetryBackoff = 100 * time.Millisecond
+	}
+	headers := make(map[string]string, len(config.Headers))
+	for k, v := range config.Headers {
+		headers[k] = v
+	}
+	config.Headers = headers
+
+	h := &HTTPHook{config: config}
+	h.queue = newHookQueue(config.BufferSize, config.BatchSize, config.FlushInterval, h.send)
+	return h, nil
+}
+
+// Level returns the minimum level of forwarded entries
+func (h *HTTPHook) Level() zapcore.Level {
+	return h.config.Level
+}
+
+// Fire queues the entry, returning an error if the queue is full
+func (h *HTTPHook) Fire(entry HookEntry) error {
+	return h.queue.push(entry)
+}
+
+// Flush sends the queued entries and waits until they are delivered or dropped
+func (h *HTTPHook) Flush() {
+	h.queue.Flush()
+}
+
+// Close sends the queued entries, including their retries, and stops the hook
+// Remove the hook with the function returned by AddHook first; entries fired afterwards are dropped
+func (h *HTTPHook) Close() error {
+	h.queue.Close()
+	return nil
+}
+
+// send posts one batch, retrying with exponential backoff on network errors and on
+// 429 and 5xx responses; other failures drop the batch
+func (h *HTTPHook) send(batch []HookEntry) {
+	body, err := json.Marshal(batch)
+	if err != nil {
+		dropBatch(batch)
+		return
+	}
+
+	backoff := h.config.RetryBackoff
+	for attempt := 0; ; attempt++ {
+		retry, err := h.post(body)
+		if err == nil {
+			return
+		}
+		if !retry || attempt >= h.config.MaxRetries {
+			dropBatch(batch)
+			return
+		}
+		time.Sleep(backoff) // Holds back the queue, so a full buffer drops entries meanwhile
+		backoff *= 2
+	}
+}
+
+// post sends a request and reports whether a failure is worth retrying
+func (h *HTTPHook) post(body []byte) (bool, error) {
+	req, err := http.NewRequest(http.MethodPost, h.config.URL, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range h.config.Headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := h.config.Client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	_, _ = io.Copy(io.Discard, resp.Body) // Allow the connection to be reused
+	_ = resp.Body.Close()
+
+	switch {
+	case resp.StatusCode < 300:
+		return false, nil
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+		return true, fmt.Errorf("log endpoint responded %s", resp.Status)
+	}
+	return false, fmt.Errorf("log endpoint rejected entries: %s", resp.Status)
+}
+
+// dropBatch counts the entries of a batch that could not be delivered
+func dropBatch(batch []HookEntry) {
+	for _, entry := range batch {
+		countHookDrops(entry.Level, 1)
+	}
+}
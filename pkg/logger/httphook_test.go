@@ -0,0 +1,204 @@
+package logger
+
+import (
+	"encoding/json"
+	stderrors "errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// batchServer is an HTTP endpoint recording the batches it accepts
+type batchServer struct {
+	*httptest.Server
+	mu       sync.Mutex
+	batches  [][]map[string]interface{}
+	requests atomic.Int32
+	status   func(request int32) int // Response status by request number, starting at 1
+}
+
+// newBatchServer starts a batch server answering with the given status function
+func newBatchServer(t *testing.T, status func(request int32) int) *batchServer {
+	t.Helper()
+	s := &batchServer{status: status}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := s.requests.Add(1)
+		if code := s.status(n); code != http.StatusOK {
+			w.WriteHeader(code)
+			return
+		}
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.Equal(t, "secret-key", r.Header.Get("X-API-Key"))
+		var batch []map[string]interface{}
+		if assert.NoError(t, json.NewDecoder(r.Body).Decode(&batch)) {
+			s.mu.Lock()
+			s.batches = append(s.batches, batch)
+			s.mu.Unlock()
+		}
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// received returns the accepted batches
+func (s *batchServer) received() [][]map[string]interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([][]map[string]interface{}(nil), s.batches...)
+}
+
+// alwaysOK accepts every request
+func alwaysOK(int32) int {
+	return http.StatusOK
+}
+
+// TestHTTPHook tests batched forwarding of logged entries
+func TestHTTPHook(t *testing.T) {
+	initializeForTest(t, LoggerConfig{AppName: "test-app", Environment: "test"})
+	server := newBatchServer(t, alwaysOK)
+
+	hook, err := NewHTTPHook(HTTPHookConfig{
+		URL:           server.URL,
+		Level:         zap.WarnLevel,
+		Headers:       map[string]string{"X-API-Key": "secret-key"},
+		BatchSize:     2,
+		FlushInterval: time.Hour,
+	})
+	require.NoError(t, err)
+	defer AddHook(hook)()
+
+	Info("not forwarded", nil)
+	Warn("first warning", Fields{"attempt": 1})
+	Warn("second warning", nil)
+	assert.Eventually(t, func() bool { return len(server.received()) == 1 }, time.Second, 5*time.Millisecond,
+		"a full batch is sent at once")
+
+	LogErr("partial batch", stderrors.New("boom"), nil)
+	require.NoError(t, hook.Close())
+
+	batches := server.received()
+	require.Len(t, batches, 2, "closing sends the partial batch")
+	require.Len(t, batches[0], 2)
+	assert.Equal(t, "first warning", batches[0][0]["msg"])
+	assert.Equal(t, "warn", batches[0][0]["level"])
+	assert.Equal(t, 1.0, batches[0][0]["attempt"])
+	assert.Equal(t, "test-app", batches[0][0]["app_name"])
+	assert.Equal(t, "partial batch", batches[1][0]["msg"])
+	assert.Equal(t, "error", batches[1][0]["level"])
+
+	before := GetDropStats()
+	Warn("after close", nil)
+	assert.Equal(t, map[string]uint64{"warn": 1}, GetDropStats().since(before).Hooks)
+}
+
+// TestHTTPHookRetries tests retrying failed batches and dropping those that keep failing
+func TestHTTPHookRetries(t *testing.T) {
+	tests := []struct {
+		name             string
+		status           func(request int32) int
+		maxRetries       int
+		expectedRequests int32
+		expectedBatches  int
+		expectedDrops    uint64
+	}{
+		{
+			name: "retried until accepted",
+			status: func(n int32) int {
+				if n < 3 {
+					return http.StatusServiceUnavailable
+				}
+				return http.StatusOK
+			},
+			maxRetries:       3,
+			expectedRequests: 3,
+			expectedBatches:  1,
+		},
+		{
+			name:             "too many requests exhaust the retries",
+			status:           func(int32) int { return http.StatusTooManyRequests },
+			maxRetries:       2,
+			expectedRequests: 3,
+			expectedDrops:    2,
+		},
+		{
+			name:             "client errors are not retried",
+			status:           func(int32) int { return http.StatusBadRequest },
+			maxRetries:       3,
+			expectedRequests: 1,
+			expectedDrops:    2,
+		},
+		{
+			name:             "retries disabled",
+			status:           func(int32) int { return http.StatusInternalServerError },
+			maxRetries:       -1,
+			expectedRequests: 1,
+			expectedDrops:    2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newBatchServer(t, tt.status)
+			hook, err := NewHTTPHook(HTTPHookConfig{
+				URL:          server.URL,
+				Headers:      map[string]string{"X-API-Key": "secret-key"},
+				MaxRetries:   tt.maxRetries,
+				RetryBackoff: time.Millisecond,
+			})
+			require.NoError(t, err)
+			before := GetDropStats()
+
+			require.NoError(t, hook.Fire(HookEntry{Level: zap.InfoLevel, Message: "one"}))
+			require.NoError(t, hook.Fire(HookEntry{Level: zap.InfoLevel, Message: "two"}))
+			hook.Flush()
+
+			assert.Equal(t, tt.expectedRequests, server.requests.Load())
+			assert.Len(t, server.received(), tt.expectedBatches)
+			assert.Equal(t, tt.expectedDrops, GetDropStats().since(before).Total)
+			require.NoError(t, hook.Close())
+		})
+	}
+}
+
+// TestHTTPHookBackPressure tests that a slow endpoint never blocks logging
+func TestHTTPHookBackPressure(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+
+	hook, err := NewHTTPHook(HTTPHookConfig{URL: server.URL, BufferSize: 5, BatchSize: 1})
+	require.NoError(t, err)
+	before := GetDropStats()
+
+	start := time.Now()
+	var full int
+	for i := 0; i < 50; i++ {
+		if hook.Fire(HookEntry{Level: zap.WarnLevel, Message: "flood"}) != nil {
+			full++
+		}
+	}
+	assert.Less(t, time.Since(start), time.Second, "firing never waits for the endpoint")
+	assert.GreaterOrEqual(t, full, 50-5-1, "entries beyond the buffer and the batch in flight are rejected")
+
+	// Rejected entries are counted when fired through the logger's hook core
+	fireHook(hook, HookEntry{Level: zap.WarnLevel, Message: "flood"})
+	assert.Equal(t, map[string]uint64{"warn": 1}, GetDropStats().since(before).Hooks)
+
+	close(release)
+	require.NoError(t, hook.Close())
+}
+
+// TestNewHTTPHookValidation tests the required configuration
+func TestNewHTTPHookValidation(t *testing.T) {
+	_, err := NewHTTPHook(HTTPHookConfig{})
+	assert.Error(t, err)
+}
@@ -0,0 +1,209 @@
+package logger
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	"github.com/BhaveshKaushal/base-lib/pkg/errors"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// =============================================================================
+// LOGGER INSTANCES
+// =============================================================================
+
+// Logger writes structured log entries with a set of bound fields and an optional name
+// Components create one with With or Named and keep it, so their fields are converted
+// once instead of on every call. Loggers are immutable values and safe to share
+// The zero value logs through the package-level logger without extra fields
+type Logger struct {
+	name   string       // Dotted component name, e.g. "payments.refunds"
+	fields []zap.Field  // Fields bound with With, converted once
+	bound  *boundLogger // Cached zap logger, nil for the default instance
+	root   *zap.Logger  // Fixed root set by WithCore, nil to follow the package-level logger
+}
+
+// callerSkip is the number of frames between the application's call and zap's Check:
+// every exported logging function calls one unexported method of Logger (log, logw,
+// logError or logFatal), which calls Check itself, so entries report the application's
+// file and line whichever function or method was used
+const callerSkip = 2
+
+// boundLogger caches the zap loggers of a Logger built on the current root logger
+// They are rebuilt when the root logger is replaced by Initialize, SetLogLevel or SetFormatter
+type boundLogger struct {
+	cached atomic.Pointer[boundLoggers]
+}
+
+// boundLoggers are the zap loggers of a Logger for one root logger
+type boundLoggers struct {
+	root   *zap.Logger
+	logger *zap.Logger // Returned by Zap, reports the caller of zap's methods
+	writer *zap.Logger // Used by the logging functions, skips the package's frames, see callerSkip
+}
+
+// defaultLogger is the instance the package-level logging functions delegate to
+var defaultLogger = Logger{bound: &boundLogger{}}
+
+// Default returns the default logger used by the package-level functions
+func Default() Logger {
+	return defaultLogger
+}
+
+// WithCore returns a logger writing to the given core instead of the package-level logger,
+// e.g. an observer core in tests; Initialize and the other configuration functions do not affect it
+func WithCore(core zapcore.Core) Logger {
+	return Logger{root: zap.New(core), bound: &boundLogger{}}
+}
+
+// With returns a logger derived from the default logger with the given fields bound
+func With(fields Fields) Logger {
+	return defaultLogger.With(fields)
+}
+
+// Named returns a logger derived from the default logger for a component
+func Named(component string) Logger {
+	return defaultLogger.Named(component)
+}
+
+// With returns a child logger with the given fields bound to every entry
+// The fields are converted immediately, so later changes to the map have no effect
+func (l Logger) With(fields Fields) Logger {
+	if len(fields) == 0 {
+		return l
+	}
+	bound := make([]zap.Field, 0, len(l.fields)+len(fields))
+	bound = append(bound, l.fields...)
+	bound = append(bound, fieldsToZapFields(fields)...)
+	return Logger{name: l.name, fields: bound, bound: &boundLogger{}, root: l.root}
+}
+
+// Named returns a child logger for a component; names of nested children are joined
+// with dots and written as the "logger" key of every entry
+func (l Logger) Named(component string) Logger {
+	if component == "" {
+		return l
+	}
+	name := component
+	if l.name != "" {
+		name = l.name + "." + component
+	}
+	return Logger{name: name, fields: l.fields, bound: &boundLogger{}, root: l.root}
+}
+
+// Name returns the dotted component name of the logger, empty for the default logger
+func (l Logger) Name() string {
+	return l.name
+}
+
+// Zap returns the underlying zap logger with the name and fields of this logger applied
+// Returns nil if the logger is not initialized
+func (l Logger) Zap() *zap.Logger {
+	logger, _ := l.resolve()
+	return logger
+}
+
+// resolve returns the zap logger of this logger on the current root logger and the
+// writer used by the logging functions, nil if the logger is not initialized
+// The zero value Logger has no cache and derives its writer on every call
+func (l Logger) resolve() (logger, writer *zap.Logger) {
+	root := l.root
+	if root == nil {
+		root = currentLogger()
+	}
+	if root == nil {
+		return nil, nil
+	}
+	if l.bound == nil {
+		return root, root.WithOptions(zap.AddCallerSkip(callerSkip))
+	}
+	if cached := l.bound.cached.Load(); cached != nil && cached.root == root {
+		return cached.logger, cached.writer
+	}
+
+	logger = root
+	if l.name != "" {
+		logger = logger.Named(l.name)
+	}
+	if len(l.fields) > 0 {
+		logger = logger.With(l.fields...)
+	}
+	cached := &boundLoggers{root: root, logger: logger, writer: logger.WithOptions(zap.AddCallerSkip(callerSkip))}
+	l.bound.cached.Store(cached)
+	return cached.logger, cached.writer
+}
+
+// writer returns the zap logger to write an entry with, nil if the logger is not initialized
+// The root logger is loaded once so an entry is never split across a reconfiguration
+func (l Logger) writer() *zap.Logger {
+	_, writer := l.resolve()
+	if writer == nil {
+		fmt.Println("Logger is not initialized")
+	}
+	return writer
+}
+
+// log writes an entry with the bound and given fields; the fields are only converted
+// if the level is enabled. Must be called directly by an exported logging function
+func (l Logger) log(level zapcore.Level, msg string, fields Fields) {
+	if logger := l.writer(); logger != nil {
+		if entry := logger.Check(level, msg); entry != nil {
+			entry.Write(fieldsToZapFields(fields)...)
+		}
+	}
+}
+
+// logw writes an entry with the bound and given typed fields
+// Must be called directly by an exported logging function
+func (l Logger) logw(level zapcore.Level, msg string, fields []Field) {
+	if logger := l.writer(); logger != nil {
+		if entry := logger.Check(level, msg); entry != nil {
+			entry.Write(fields...)
+		}
+	}
+}
+
+// logFatal writes an error entry at fatal level and exits the application
+// Must be called directly by an exported logging function
+func (l Logger) logFatal(msg string, err error, fields Fields) {
+	if logger := l.writer(); logger != nil {
+		// Checking at fatal level always returns an entry that calls os.Exit(1) once written
+		if entry := logger.Check(zap.FatalLevel, msg); entry != nil {
+			entry.Write(fieldsToZapFields(prepareErrorFields(err, fields))...)
+		}
+	}
+}
+
+// Debug logs a message at debug level with the bound and given fields
+func (l Logger) Debug(msg string, fields Fields) {
+	l.log(zap.DebugLevel, msg, fields)
+}
+
+// Info logs a message at info level with the bound and given fields
+func (l Logger) Info(msg string, fields Fields) {
+	l.log(zap.InfoLevel, msg, fields)
+}
+
+// Warn logs a message at warning level with the bound and given fields
+func (l Logger) Warn(msg string, fields Fields) {
+	l.log(zap.WarnLevel, msg, fields)
+}
+
+// Error logs an error at error level with the bound and given fields, see Error
+func (l Logger) Error(msg string, err error, fields Fields) {
+	l.logError(zap.ErrorLevel, msg, err, fields)
+}
+
+// LogErr logs an error at the level matching its severity with the bound and given fields, see LogErr
+func (l Logger) LogErr(msg string, err error, fields Fields) {
+	if isNilError(err) {
+		return
+	}
+	l.logError(severityLevel(errors.SeverityOf(err)), msg, err, fields)
+}
+
+// Fatal logs an error at fatal level with the bound and given fields and exits the application, see Fatal
+func (l Logger) Fatal(msg string, err error, fields Fields) {
+	l.logFatal(msg, err, fields)
+}
@@ -0,0 +1,117 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	errorcodes "github.com/BhaveshKaushal/base-lib/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// newBufferLogger creates a zap logger writing JSON entries at debug level into a buffer
+func newBufferLogger() (*zap.Logger, *bytes.Buffer) {
+	var buf bytes.Buffer
+	core := zapcore.NewCore(
+		zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig()),
+		zapcore.AddSync(&buf),
+		zapcore.DebugLevel,
+	)
+	return zap.New(core), &buf
+}
+
+// decodeEntries parses the JSON entries written to a buffer
+func decodeEntries(t *testing.T, buf *bytes.Buffer) []map[string]interface{} {
+	t.Helper()
+	var entries []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var entry map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(line), &entry))
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// TestLoggerWith tests that bound fields appear on every entry of a child logger
+func TestLoggerWith(t *testing.T) {
+	restoreLogger(t)
+	var buf *bytes.Buffer
+	var logger *zap.Logger
+	logger, buf = newBufferLogger()
+	setRootLogger(logger)
+
+	fields := Fields{"component": "billing"}
+	billing := With(fields)
+	fields["component"] = "changed"
+	invoices := billing.With(Fields{"invoice_id": "inv-1"})
+
+	billing.Info("billing message", nil)
+	invoices.Warn("invoice message", Fields{"attempt": 2})
+	invoices.Error("invoice failed", errorcodes.NewErrDefault(errorcodes.ErrCodeDBQuery, "query failed", "billing"), nil)
+	Info("plain message", nil)
+
+	entries := decodeEntries(t, buf)
+	require.Len(t, entries, 4)
+	assert.Equal(t, "billing", entries[0]["component"], "bound fields are copied when the child is created")
+	assert.NotContains(t, entries[0], "invoice_id", "children do not change their parent")
+
+	assert.Equal(t, "billing", entries[1]["component"])
+	assert.Equal(t, "inv-1", entries[1]["invoice_id"])
+	assert.Equal(t, float64(2), entries[1]["attempt"])
+
+	assert.Equal(t, "inv-1", entries[2]["invoice_id"])
+	assert.Equal(t, string(errorcodes.ErrCodeDBQuery), entries[2]["code"])
+
+	assert.NotContains(t, entries[3], "component", "the default logger has no bound fields")
+}
+
+// TestLoggerNamed tests component names of nested child loggers
+func TestLoggerNamed(t *testing.T) {
+	restoreLogger(t)
+	var buf *bytes.Buffer
+	var logger *zap.Logger
+	logger, buf = newBufferLogger()
+	setRootLogger(logger)
+
+	payments := Named("payments")
+	refunds := payments.Named("refunds").With(Fields{"region": "eu"})
+	assert.Equal(t, "payments.refunds", refunds.Name())
+	assert.Equal(t, "", Default().Name())
+
+	payments.Info("payments message", nil)
+	refunds.Debug("refunds message", nil)
+
+	entries := decodeEntries(t, buf)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "payments", entries[0]["logger"])
+	assert.Equal(t, "payments.refunds", entries[1]["logger"])
+	assert.Equal(t, "eu", entries[1]["region"])
+}
+
+// TestLoggerFollowsReconfiguration tests that child loggers use the current root logger
+func TestLoggerFollowsReconfiguration(t *testing.T) {
+	restoreLogger(t)
+	var first, second *bytes.Buffer
+	var logger *zap.Logger
+	logger, first = newBufferLogger()
+	setRootLogger(logger)
+
+	child := Named("worker").With(Fields{"worker_id": 1})
+	child.Info("before", nil)
+
+	logger, second = newBufferLogger()
+	setRootLogger(logger)
+	child.Info("after", nil)
+
+	assert.Contains(t, first.String(), "before")
+	assert.NotContains(t, first.String(), "after")
+	assert.Contains(t, second.String(), "after")
+	assert.Contains(t, second.String(), `"worker_id":1`)
+}
@@ -0,0 +1,289 @@
+package logger
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// =============================================================================
+// LEVEL OVERRIDES
+// =============================================================================
+
+// levelOverride is the level of one named logger, see SetLoggerLevel
+type levelOverride struct {
+	level     zapcore.Level
+	expiresAt time.Time      // When a temporary override reverts, zero if permanent
+	base      *levelOverride // Permanent override restored on expiry, nil removes the override
+}
+
+// overrideSet is an immutable set of level overrides by logger name
+type overrideSet struct {
+	levels map[string]levelOverride
+	min    zapcore.Level // Lowest overridden level, so Enabled can skip the name lookup
+}
+
+// globalRevert describes a temporary change of the logger level, see SetLogLevelFor
+type globalRevert struct {
+	expiresAt time.Time
+	base      zapcore.Level // Level restored on expiry
+}
+
+var (
+	// overrides holds the per-logger levels; it is replaced as a whole on every change
+	// and survives rebuilds of the root logger
+	overrides atomic.Pointer[overrideSet]
+
+	// levelGenerations counts changes per logger name, "" being the logger level, so a
+	// pending revert does not undo a later change; guarded by configMu
+	levelGenerations = map[string]uint64{}
+
+	// pendingRevert is the temporary logger level in effect, nil if permanent; guarded by configMu
+	pendingRevert *globalRevert
+)
+
+// levelFor returns the level that applies to a logger name and whether an override matched
+// Overrides apply to the named logger and its children, the longest matching name wins
+func (s *overrideSet) levelFor(name string) (zapcore.Level, bool) {
+	if s == nil || len(s.levels) == 0 {
+		return 0, false
+	}
+	for name != "" {
+		if override, ok := s.levels[name]; ok {
+			return override.level, true
+		}
+		i := strings.LastIndexByte(name, '.')
+		if i < 0 {
+			break
+		}
+		name = name[:i]
+	}
+	return 0, false
+}
+
+// with returns a copy of the set with the override for name replaced, or removed if nil
+func (s *overrideSet) with(name string, override *levelOverride) *overrideSet {
+	next := &overrideSet{levels: map[string]levelOverride{}, min: zapcore.InvalidLevel}
+	if s != nil {
+		for k, v := range s.levels {
+			next.levels[k] = v
+		}
+	}
+	if override != nil {
+		next.levels[name] = *override
+	} else {
+		delete(next.levels, name)
+	}
+	for _, v := range next.levels {
+		if v.level < next.min {
+			next.min = v.level
+		}
+	}
+	return next
+}
+
+// levelCore applies the logger level and the per-logger overrides in front of the sinks
+// Sinks only apply their own minimum level, so an override can enable entries below the logger level
+type levelCore struct {
+	zapcore.Core
+	level zap.AtomicLevel
+}
+
+// Enabled reports whether any logger could write entries of the level
+func (c *levelCore) Enabled(l zapcore.Level) bool {
+	if c.level.Enabled(l) {
+		return true
+	}
+	set := overrides.Load()
+	return set != nil && len(set.levels) > 0 && l >= set.min
+}
+
+// Level returns the lowest level any logger writes, see zapcore.LevelOf
+func (c *levelCore) Level() zapcore.Level {
+	level := c.level.Level()
+	if set := overrides.Load(); set != nil && len(set.levels) > 0 && set.min < level {
+		return set.min
+	}
+	return level
+}
+
+// Check adds the sinks to the entry if it meets the level of its logger
+func (c *levelCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if level, ok := overrides.Load().levelFor(ent.LoggerName); ok {
+		if ent.Level < level {
+			return ce
+		}
+	} else if !c.level.Enabled(ent.Level) {
+		return ce
+	}
+	return c.Core.Check(ent, ce)
+}
+
+// With adds fields to the wrapped core, keeping the level filtering
+func (c *levelCore) With(fields []zapcore.Field) zapcore.Core {
+	return &levelCore{Core: c.Core.With(fields), level: c.level}
+}
+
+// =============================================================================
+// PUBLIC LEVEL FUNCTIONS
+// =============================================================================
+
+// LevelSetting is a level and, for temporary changes, when it reverts
+type LevelSetting struct {
+	Level     string     `json:"level"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// LevelStatus describes the logger level and the per-logger overrides
+type LevelStatus struct {
+	LevelSetting
+	Overrides map[string]LevelSetting `json:"overrides,omitempty"`
+}
+
+// SetLogLevelFor changes the logger level like SetLogLevel but rejects unknown level names
+// With a positive ttl the change reverts to the last permanent level once it expires,
+// e.g. to enable debug logging in production for a limited time
+func SetLogLevelFor(level string, ttl time.Duration) error {
+	lvl, ok := lookupLevel(level)
+	if !ok {
+		return fmt.Errorf("unknown log level %q", level)
+	}
+	if currentLogger() == nil {
+		return fmt.Errorf("logger is not initialized")
+	}
+
+	configMu.Lock()
+	defer configMu.Unlock()
+	setGlobalLevel(lvl, ttl)
+	return nil
+}
+
+// SetLoggerLevel overrides the level of a named logger and its children, e.g. "payments"
+// also applies to "payments.refunds"; an empty level removes the override
+// With a positive ttl the change reverts to the last permanent setting once it expires
+func SetLoggerLevel(name, level string, ttl time.Duration) error {
+	if name == "" {
+		return fmt.Errorf("logger name is empty")
+	}
+	var lvl zapcore.Level
+	if level != "" {
+		var ok bool
+		if lvl, ok = lookupLevel(level); !ok {
+			return fmt.Errorf("unknown log level %q for logger %q", level, name)
+		}
+	}
+
+	configMu.Lock()
+	defer configMu.Unlock()
+
+	generation := nextLevelGeneration(name)
+	if level == "" {
+		overrides.Store(overrides.Load().with(name, nil))
+		return nil
+	}
+
+	override := &levelOverride{level: lvl}
+	if ttl > 0 {
+		override.expiresAt = time.Now().Add(ttl)
+		override.base = permanentOverride(name)
+		time.AfterFunc(ttl, func() {
+			configMu.Lock()
+			defer configMu.Unlock()
+			if levelGenerations[name] != generation {
+				return // Changed again in the meantime
+			}
+			nextLevelGeneration(name)
+			overrides.Store(overrides.Load().with(name, override.base))
+		})
+	}
+	overrides.Store(overrides.Load().with(name, override))
+	return nil
+}
+
+// Levels returns the logger level and the per-logger overrides in effect
+func Levels() LevelStatus {
+	configMu.Lock()
+	defer configMu.Unlock()
+
+	status := LevelStatus{LevelSetting: LevelSetting{Level: loadState().currentOptions().Level.String()}}
+	if pendingRevert != nil {
+		expiresAt := pendingRevert.expiresAt
+		status.ExpiresAt = &expiresAt
+	}
+	if set := overrides.Load(); set != nil && len(set.levels) > 0 {
+		status.Overrides = make(map[string]LevelSetting, len(set.levels))
+		for name, override := range set.levels {
+			setting := LevelSetting{Level: override.level.String()}
+			if !override.expiresAt.IsZero() {
+				expiresAt := override.expiresAt
+				setting.ExpiresAt = &expiresAt
+			}
+			status.Overrides[name] = setting
+		}
+	}
+	return status
+}
+
+// setGlobalLevel changes the level of the current logger, reverting after ttl if positive
+// Must be called with configMu held
+func setGlobalLevel(lvl zapcore.Level, ttl time.Duration) {
+	current := loadState()
+	base := current.level.Level()
+	if pendingRevert != nil {
+		base = pendingRevert.base // Revert to the permanent level, not an earlier temporary one
+	}
+
+	generation := nextLevelGeneration("")
+	current.level.SetLevel(lvl)
+	pendingRevert = nil
+	if ttl <= 0 {
+		return
+	}
+
+	pendingRevert = &globalRevert{expiresAt: time.Now().Add(ttl), base: base}
+	time.AfterFunc(ttl, func() {
+		configMu.Lock()
+		defer configMu.Unlock()
+		if levelGenerations[""] != generation {
+			return // Changed again in the meantime
+		}
+		nextLevelGeneration("")
+		loadState().level.SetLevel(base)
+		pendingRevert = nil
+	})
+}
+
+// cancelLevelRevert drops a pending revert of the logger level, e.g. when Initialize or
+// SetOptions set a new level; must be called with configMu held
+func cancelLevelRevert() {
+	nextLevelGeneration("")
+	pendingRevert = nil
+}
+
+// permanentOverride returns the permanent override of name beneath any temporary one
+// Must be called with configMu held
+func permanentOverride(name string) *levelOverride {
+	set := overrides.Load()
+	if set == nil {
+		return nil
+	}
+	current, ok := set.levels[name]
+	if !ok {
+		return nil
+	}
+	if !current.expiresAt.IsZero() {
+		return current.base
+	}
+	return &current
+}
+
+// nextLevelGeneration records a change of the level of name and returns its generation
+// Must be called with configMu held
+func nextLevelGeneration(name string) uint64 {
+	levelGenerations[name]++
+	return levelGenerations[name]
+}
@@ -0,0 +1,114 @@
+package logger
+
+import (
+	"testing"
+	"time"
+
+	errorcodes "github.com/BhaveshKaushal/base-lib/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// resetLevels removes all level overrides and pending reverts when the test ends
+func resetLevels(t *testing.T) {
+	t.Cleanup(func() {
+		configMu.Lock()
+		defer configMu.Unlock()
+		overrides.Store(nil)
+		for name := range levelGenerations {
+			levelGenerations[name]++ // Cancel pending reverts
+		}
+		pendingRevert = nil
+	})
+}
+
+// TestLoggerLevelOverrides tests per-logger levels for named loggers and their children
+func TestLoggerLevelOverrides(t *testing.T) {
+	resetLevels(t)
+	path := initializeForTest(t, LoggerConfig{AppName: "test-app", Environment: "test"})
+
+	require.NoError(t, SetLoggerLevel("payments", "debug", 0))
+	require.NoError(t, SetLoggerLevel("orders", "error", 0))
+
+	payments := Named("payments")
+	payments.Debug("payments debug", nil)
+	payments.Named("refunds").Debug("refunds debug", nil)
+	Named("paymentsx").Debug("other component debug", nil)
+	Named("orders").Warn("orders warning", nil)
+	Named("orders").Error("orders error", errorcodes.NewErrDefault(errorcodes.ErrCodeDBQuery, "query failed", "test-app"), nil)
+	Debug("root debug", nil)
+	Info("root info", nil)
+
+	output := readLog(t, path)
+	assert.Contains(t, output, "payments debug")
+	assert.Contains(t, output, "refunds debug", "overrides apply to child loggers")
+	assert.NotContains(t, output, "other component debug", "names match on dot boundaries only")
+	assert.NotContains(t, output, "orders warning", "overrides can raise the level")
+	assert.Contains(t, output, "orders error")
+	assert.NotContains(t, output, "root debug")
+	assert.Contains(t, output, "root info")
+
+	// The longest matching name wins and overrides survive a rebuild
+	require.NoError(t, SetLoggerLevel("payments.refunds", "warn", 0))
+	SetFormatter("json")
+	payments.Named("refunds").Info("refunds info", nil)
+	payments.Info("payments info", nil)
+	output = readLog(t, path)
+	assert.NotContains(t, output, "refunds info")
+	assert.Contains(t, output, "payments info")
+
+	// Removing an override restores the logger level
+	require.NoError(t, SetLoggerLevel("payments", "", 0))
+	payments.Debug("removed override debug", nil)
+	assert.NotContains(t, readLog(t, path), "removed override debug")
+
+	status := Levels()
+	assert.Equal(t, "info", status.Level)
+	assert.Nil(t, status.ExpiresAt)
+	assert.Equal(t, map[string]LevelSetting{
+		"orders":           {Level: "error"},
+		"payments.refunds": {Level: "warn"},
+	}, status.Overrides)
+
+	assert.Error(t, SetLoggerLevel("", "debug", 0))
+	assert.Error(t, SetLoggerLevel("payments", "verbose", 0))
+	assert.Error(t, SetLogLevelFor("verbose", 0))
+}
+
+// TestLevelTTL tests that temporary level changes revert to the last permanent setting
+func TestLevelTTL(t *testing.T) {
+	resetLevels(t)
+	initializeForTest(t, LoggerConfig{AppName: "test-app", Environment: "test"})
+
+	// Temporary changes of the logger level revert to the permanent level
+	require.NoError(t, SetLogLevelFor("debug", 20*time.Millisecond))
+	require.NoError(t, SetLogLevelFor("warn", 20*time.Millisecond))
+	status := Levels()
+	assert.Equal(t, "warn", status.Level)
+	require.NotNil(t, status.ExpiresAt)
+	assert.Eventually(t, func() bool { return GetOptions().Level == zap.InfoLevel }, time.Second, 5*time.Millisecond)
+	assert.Nil(t, Levels().ExpiresAt)
+
+	// A later permanent change cancels a pending revert
+	require.NoError(t, SetLogLevelFor("debug", 20*time.Millisecond))
+	SetLogLevel("error")
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, zap.ErrorLevel, GetOptions().Level)
+
+	// Temporary overrides revert to the permanent override beneath them
+	require.NoError(t, SetLoggerLevel("payments", "warn", 0))
+	require.NoError(t, SetLoggerLevel("payments", "debug", 20*time.Millisecond))
+	require.NoError(t, SetLoggerLevel("payments", "info", 20*time.Millisecond))
+	assert.NotNil(t, Levels().Overrides["payments"].ExpiresAt)
+	assert.Eventually(t, func() bool {
+		return Levels().Overrides["payments"] == LevelSetting{Level: "warn"}
+	}, time.Second, 5*time.Millisecond)
+
+	// Temporary overrides without a permanent one are removed
+	require.NoError(t, SetLoggerLevel("orders", "debug", 20*time.Millisecond))
+	assert.Eventually(t, func() bool {
+		_, ok := Levels().Overrides["orders"]
+		return !ok
+	}, time.Second, 5*time.Millisecond)
+}
@@ -1,9 +1,12 @@
 package logger
 
 import (
-	"context"
+	stderrors "errors"
 	"fmt"
+	"reflect"
 	"strings"
+	"sync"
+	"sync/atomic"
 
 	"github.com/BhaveshKaushal/base-lib/pkg/errors"
 	"go.uber.org/zap"
@@ -16,16 +19,57 @@ import (
 
 // Global variables for logger state management
 var (
-	// zapLogger is the global zap logger instance used throughout the application
-	zapLogger *zap.Logger
+	// state holds the current logger snapshot; it is replaced as a whole on reconfiguration
+	// so logging goroutines never observe a half-updated logger
+	state atomic.Pointer[loggerState]
 
-	// defaultFields contains application-level metadata that gets added to every log entry
-	defaultFields = map[string]interface{}{
+	// configMu serializes reconfiguration so concurrent Initialize, SetLogLevel,
+	// SetFormatter and SetOptions calls do not lose each other's changes
+	configMu sync.Mutex
+
+	// errorThrottle emits only every Nth occurrence of an identical error (0 or 1 disables throttling)
+	errorThrottle atomic.Int64
+
+	// recordSpanErrors records errors logged with a context on its OpenTelemetry span, see SetSpanErrorRecording
+	recordSpanErrors atomic.Bool
+
+	// errorTracker counts error occurrences per fingerprint for throttling
+	errorTracker = errors.NewErrorTracker()
+
+	// alertHook is called for every logged error of critical severity, see SetAlertHook
+	alertHookMu sync.RWMutex
+	alertHook   AlertHook
+)
+
+// loggerState is an immutable snapshot of the logger configuration
+// Neither the logger, the defaults map nor the options may be modified once the snapshot
+// is stored; only the level is changed in place by SetLogLevel
+type loggerState struct {
+	zap       *zap.Logger     // Root logger with the default fields bound
+	unsampled *zap.Logger     // Root logger bypassing sampling and rate limits, for drop reports
+	defaults  Fields          // Application-level metadata added to every log entry
+	options   Options         // Options the root logger was built from
+	level     zap.AtomicLevel // Live minimum level of the root logger, see SetLogLevel
+	files     fileSet         // Log files the root logger writes to, closed when no longer used
+}
+
+// currentOptions returns a copy of the options with the live level
+func (s *loggerState) currentOptions() Options {
+	opts := s.options.normalized()
+	if s.zap != nil {
+		opts.Level = s.level.Level()
+	}
+	return opts
+}
+
+// initialDefaultFields returns the default fields used before Initialize is called
+func initialDefaultFields() Fields {
+	return Fields{
 		"app_name":    "unknown", // Application name identifier
 		"app_version": "unknown", // Application version for tracking deployments
 		"environment": "local",   // Environment (dev, staging, prod) for filtering logs
 	}
-)
+}
 
 // =============================================================================
 // PUBLIC TYPES
@@ -37,8 +81,36 @@ type LoggerConfig struct {
 	AppName     string // Name of the application
 	AppVersion  string // Version of the application (e.g., "1.0.0")
 	Environment string // Environment where the app is running (e.g., "production")
+
+	// ErrorThrottle emits only every Nth identical Error call with an "occurrences" count
+	// Identical means the same error fingerprint; 0 or 1 logs every call
+	ErrorThrottle int
+
+	// RecordSpanErrors records errors logged by ErrorCtx and LogErrCtx on the OpenTelemetry
+	// span in the context, see SetSpanErrorRecording
+	RecordSpanErrors bool
+
+	// Outputs lists the sinks log entries are written to, e.g. stdout plus a rotated
+	// file for errors; when set it replaces Options.Outputs
+	Outputs []OutputConfig
+
+	// Sampling limits repeated messages and entries per level, e.g. for hot loops;
+	// when set it replaces Options.Sampling, which defaults to DefaultSamplingConfig()
+	Sampling *SamplingConfig
+
+	// Masking redacts passwords, tokens, card numbers and similar values in fields;
+	// when set it replaces Options.Masking, which defaults to DefaultMaskingConfig()
+	Masking *MaskingConfig
+
+	// Options controls level, encoding, outputs, sampling, caller and stack traces
+	// nil uses DefaultOptions()
+	Options *Options
 }
 
+// AlertHook is notified of every logged error with critical severity
+// It receives the log message, the error and the fields written with it
+type AlertHook func(msg string, err error, fields Fields)
+
 // Fields type alias for structured logging key-value pairs
 // Use this to provide context and metadata with your log entries
 type Fields map[string]interface{}
@@ -47,34 +119,6 @@ type Fields map[string]interface{}
 // PRIVATE HELPER FUNCTIONS
 // =============================================================================
 
-// createZapConfig creates a standardized zap configuration with common settings
-func createZapConfig(level zapcore.Level) zap.Config {
-	zapConfig := zap.NewProductionConfig()
-	zapConfig.OutputPaths = []string{"stdout"}                      // Log to standard output
-	zapConfig.ErrorOutputPaths = []string{"stderr"}                 // Error logs to standard error
-	zapConfig.EncoderConfig.TimeKey = "timestamp"                   // Use "timestamp" as the time field key
-	zapConfig.EncoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder // ISO8601 time format
-	zapConfig.Level = zap.NewAtomicLevelAt(level)                   // Set the specified level
-	return zapConfig
-}
-
-// addDefaultFields merges application default fields with user-provided fields
-// User-provided fields take precedence over defaults if there are conflicts
-func addDefaultFields(fields Fields) Fields {
-	if fields == nil {
-		fields = Fields{}
-	}
-
-	// Add default fields only if they don't already exist in user fields
-	// This allows users to override default values if needed
-	for k, v := range defaultFields {
-		if _, exists := fields[k]; !exists {
-			fields[k] = v
-		}
-	}
-	return fields
-}
-
 // fieldsToZapFields converts our Fields map to zap's native field format
 // This enables type-safe and efficient field handling in zap
 func fieldsToZapFields(fields Fields) []zap.Field {
@@ -92,14 +136,20 @@ func fieldsToZapFields(fields Fields) []zap.Field {
 }
 
 // prepareErrorFields prepares fields for error logging with standardized error information
-// Uses the custom Error interface from the errors package
-func prepareErrorFields(err errors.Error, fields Fields) Fields {
-	if fields == nil {
-		fields = Fields{}
+// Plain errors get the fields of their outermost coded error, ErrCodeUnknown if there is none
+// A nil error adds no error fields; the caller's map is copied, never modified
+func prepareErrorFields(err error, callerFields Fields) Fields {
+	fields := make(Fields, len(callerFields)+8)
+	for k, v := range callerFields {
+		fields[k] = v
 	}
+	if isNilError(err) {
+		return fields
+	}
+	coded := asCodedError(err)
 
 	// Get error code from the custom error
-	code := err.Code()
+	code := coded.Code()
 
 	// Validate and set error code - use unknown if invalid
 	if !errors.IsValidCode(code) {
@@ -108,32 +158,219 @@ func prepareErrorFields(err errors.Error, fields Fields) Fields {
 
 	// Add standardized error information to fields
 	fields["code"] = code
+	fields["category"] = code.Category()
 	fields["code_description"] = errors.GetCodeDescription(code)
-	fields["error_message"] = err.Message()
-	fields["error_cause"] = err.Cause().Error()
-	fields["app"] = err.Er().Error() // The underlying error message
+	fields["error_message"] = coded.Message()
+	if cause := coded.Cause(); !isNilError(cause) {
+		fields["error_cause"] = cause.Error()
+	}
+	if chain := errorChain(err); len(chain) > 0 {
+		fields["error_chain"] = chain
+	}
+	if app := errorApp(err); app != "" {
+		fields["error_app"] = app
+	}
 
-	return addDefaultFields(fields)
+	// Aggregated errors list every member so individual failures stay searchable
+	if multi, ok := coded.(*errors.MultiErr); ok {
+		members := make([]map[string]interface{}, 0, multi.Len())
+		for _, member := range multi.Errors() {
+			members = append(members, map[string]interface{}{
+				"code":          member.Code(),
+				"error_message": member.Message(),
+				"error":         member.Error(),
+			})
+		}
+		fields["errors"] = members
+	}
+
+	return fields
+}
+
+// maxErrorChain bounds the number of errors followed by errorChain and errorApp
+const maxErrorChain = 32
+
+// errorChain returns the messages of err and the errors it wraps, outermost first
+// Links are followed through Unwrap, then Cause; a message repeating the previous one is
+// skipped, so an error wrapped with fmt.Errorf("%w") does not appear twice
+// Coded errors without underlying error contribute their message
+func errorChain(err error) []string {
+	var chain []string
+	for i := 0; i < maxErrorChain && !isNilError(err); i++ {
+		text := err.Error()
+		if coded, ok := err.(errors.Error); ok && text == "" {
+			text = coded.Message()
+		}
+		if text != "" && (len(chain) == 0 || chain[len(chain)-1] != text) {
+			chain = append(chain, text)
+		}
+		err = nextError(err)
+	}
+	return chain
 }
 
-// createLoggerWithDefaultFields creates a new logger with default fields applied
-func createLoggerWithDefaultFields(zapConfig zap.Config) (*zap.Logger, error) {
-	newLogger, err := zapConfig.Build()
+// errorApp returns the app of the outermost error in err's chain that names one
+func errorApp(err error) string {
+	for i := 0; i < maxErrorChain && !isNilError(err); i++ {
+		if e, ok := err.(interface{ App() string }); ok && e.App() != "" {
+			return e.App()
+		}
+		err = nextError(err)
+	}
+	return ""
+}
+
+// nextError returns the error wrapped by err, nil at the end of the chain
+// Aggregated errors end the chain; their members are logged separately
+func nextError(err error) error {
+	switch e := err.(type) {
+	case interface{ Unwrap() error }:
+		return e.Unwrap()
+	case interface{ Cause() error }:
+		return e.Cause()
+	}
+	return nil
+}
+
+// isNilError reports whether err is nil, including typed nil pointers such as a nil *errors.Err
+// held in an error interface, whose methods would panic
+func isNilError(err error) bool {
+	if err == nil {
+		return true
+	}
+	value := reflect.ValueOf(err)
+	return value.Kind() == reflect.Ptr && value.IsNil()
+}
+
+// loadState returns the current logger snapshot, an empty one before initialization
+func loadState() *loggerState {
+	if s := state.Load(); s != nil {
+		return s
+	}
+	return &loggerState{}
+}
+
+// currentLogger returns the current root zap logger, nil if the logger is not initialized
+func currentLogger() *zap.Logger {
+	return loadState().zap
+}
+
+// swapState builds a root logger from the options and stores it as the new state
+// The previous logger is synced so buffered entries are not lost, and log files
+// it no longer shares with the new one are closed
+// Must be called with configMu held
+func swapState(defaults Fields, opts Options, level zap.AtomicLevel) error {
+	previous := loadState()
+	next, err := opts.build(level, defaults, previous.files)
 	if err != nil {
-		return nil, err
+		return err // Keep existing logger if new one fails to build
+	}
+
+	state.Store(next)
+	if previous.zap != nil {
+		_ = previous.zap.Sync()
 	}
+	previous.files.closeExcept(next.files)
+	startDropReporter(opts)
+	return nil
+}
 
-	// Add default fields to the new logger to preserve them
-	fields := make([]zap.Field, 0, len(defaultFields))
-	for k, v := range defaultFields {
-		fields = append(fields, zap.Any(k, v))
+// throttleError records an error occurrence and decides whether it should be logged
+// Returns the occurrence count of the error's fingerprint when throttling is enabled
+func throttleError(err errors.Error) (uint64, bool) {
+	n := errorThrottle.Load()
+	if n <= 1 {
+		return 0, true
 	}
-	return newLogger.With(fields...), nil
+	stats := errorTracker.Track(err)
+	return stats.Count, (stats.Count-1)%uint64(n) == 0
+}
+
+// severityLevel maps an error severity to the zap level it is logged at
+// Critical errors are logged at error level; their urgency is signalled by the alert hook
+func severityLevel(severity errors.Severity) zapcore.Level {
+	switch severity {
+	case errors.SeverityDebug:
+		return zap.DebugLevel
+	case errors.SeverityInfo:
+		return zap.InfoLevel
+	case errors.SeverityWarn:
+		return zap.WarnLevel
+	}
+	return zap.ErrorLevel
+}
+
+// asCodedError returns the outermost coded error in err's chain
+// Errors without a code are wrapped as ErrCodeUnknown so they get the standard error fields
+func asCodedError(err error) errors.Error {
+	var coded errors.Error
+	if stderrors.As(err, &coded) {
+		return coded
+	}
+	return errors.NewErr(errors.ErrCodeUnknown, err, err.Error(), "")
+}
+
+// logError writes an error entry at the given level and fires the alert hook for critical errors
+// The level is checked first, so only entries that pass it count towards the error throttle;
+// repeated identical errors are then throttled according to the configured error throttle
+// A nil error writes the message and fields only
+// Reports whether an entry for a non-nil error was written, so callers can record it elsewhere
+// Must be called directly by an exported logging function, see callerSkip
+func (l Logger) logError(level zapcore.Level, msg string, err error, fields Fields) bool {
+	logger := l.writer()
+	if logger == nil {
+		return false
+	}
+	entry := logger.Check(level, msg)
+	if entry == nil {
+		return false
+	}
+	if isNilError(err) {
+		entry.Write(fieldsToZapFields(fields)...)
+		return false
+	}
+	occurrences, emit := throttleError(asCodedError(err))
+	if !emit {
+		return false
+	}
+
+	severity := errors.SeverityOf(err)
+	fields = prepareErrorFields(err, fields)
+	fields["severity"] = severity
+	if occurrences > 0 {
+		fields["occurrences"] = occurrences
+	}
+	entry.Write(fieldsToZapFields(fields)...)
+
+	if severity == errors.SeverityCritical {
+		fireAlert(msg, err, fields)
+	}
+	return true
+}
+
+// fireAlert hands a critical error to the registered alert hook
+// A panicking hook must not take down the caller that was only trying to log
+func fireAlert(msg string, err error, fields Fields) {
+	alertHookMu.RLock()
+	hook := alertHook
+	alertHookMu.RUnlock()
+
+	if hook == nil {
+		return
+	}
+	defer func() { _ = recover() }()
+	hook(msg, err, fields)
+}
+
+// logPanic reports a panic recovered by the errors package together with its
+// details (panic value, stack and request data for HTTP handlers)
+func logPanic(err *errors.Err) {
+	defaultLogger.logError(zap.ErrorLevel, "Recovered from panic", err, Fields(err.Details()))
 }
 
 // checkLoggerInitialized checks if logger is initialized and handles nil case consistently
 func checkLoggerInitialized() bool {
-	if zapLogger == nil {
+	if currentLogger() == nil {
 		fmt.Println("Logger is not initialized")
 		return false
 	}
@@ -147,33 +384,50 @@ func checkLoggerInitialized() bool {
 // Initialize sets up the zap logger with application-specific configuration
 // This should be called once at application startup before any logging occurs
 func Initialize(config LoggerConfig) {
-	// Update default fields with provided configuration
+	configMu.Lock()
+	defer configMu.Unlock()
+
+	// Build a fresh defaults snapshot from the current one and the provided configuration
 	// These fields will be automatically added to every log entry
-	defaultFields["app_name"] = config.AppName
+	defaults := initialDefaultFields()
+	for k, v := range loadState().defaults {
+		defaults[k] = v
+	}
+	defaults["app_name"] = config.AppName
 	if config.AppVersion != "" {
-		defaultFields["app_version"] = config.AppVersion
+		defaults["app_version"] = config.AppVersion
 	}
 	if config.Environment != "" {
-		defaultFields["environment"] = config.Environment
+		defaults["environment"] = config.Environment
 	}
 
-	// Create production-ready zap configuration
-	zapConfig := createZapConfig(zap.InfoLevel) // Default to Info level
+	// Start from production-ready options unless the application provides its own
+	// Local development gets the pretty console encoder instead of JSON
+	opts := DefaultOptions()
+	if config.Options != nil {
+		opts = *config.Options
+	} else if strings.EqualFold(config.Environment, "local") {
+		opts.Encoding = EncodingPretty
+	}
+	if len(config.Outputs) > 0 {
+		opts.Outputs = config.Outputs
+	}
+	if config.Sampling != nil {
+		opts.Sampling = config.Sampling
+	}
+	if config.Masking != nil {
+		opts.Masking = config.Masking
+	}
+	opts = opts.normalized()
 
-	// Build the logger instance
-	var err error
-	zapLogger, err = zapConfig.Build()
-	if err != nil {
+	// Build the logger instance with the default fields on every log entry
+	errorThrottle.Store(int64(config.ErrorThrottle))
+	recordSpanErrors.Store(config.RecordSpanErrors)
+	if err := swapState(defaults, opts, zap.NewAtomicLevelAt(opts.Level)); err != nil {
 		// Logger initialization failure is critical - panic to prevent silent failures
 		panic("Failed to initialize zap logger: " + err.Error())
 	}
-
-	// Add default fields to every log entry by creating a new logger with these fields
-	fields := make([]zap.Field, 0, len(defaultFields))
-	for k, v := range defaultFields {
-		fields = append(fields, zap.Any(k, v))
-	}
-	zapLogger = zapLogger.With(fields...)
+	cancelLevelRevert()
 }
 
 // =============================================================================
@@ -184,56 +438,47 @@ func Initialize(config LoggerConfig) {
 // Debug logs are typically used for detailed diagnostic information
 // Only logged when log level is set to debug
 func Debug(msg string, fields Fields) {
-	if !checkLoggerInitialized() {
-		return
-	}
-	fields = addDefaultFields(fields)
-	zapLogger.Debug(msg, fieldsToZapFields(fields)...)
+	defaultLogger.log(zap.DebugLevel, msg, fields)
 }
 
 // Info logs a message at info level with structured fields
 // Info logs are for general application flow and important events
 func Info(msg string, fields Fields) {
-	if !checkLoggerInitialized() {
-		return
-	}
-	fields = addDefaultFields(fields)
-	zapLogger.Info(msg, fieldsToZapFields(fields)...)
+	defaultLogger.log(zap.InfoLevel, msg, fields)
 }
 
 // Warn logs a message at warning level with structured fields
 // Warning logs indicate potential issues that don't prevent operation
 func Warn(msg string, fields Fields) {
-	if !checkLoggerInitialized() {
-		return
-	}
-	fields = addDefaultFields(fields)
-	zapLogger.Warn(msg, fieldsToZapFields(fields)...)
+	defaultLogger.log(zap.WarnLevel, msg, fields)
 }
 
-// Error logs a message at error level with custom error and structured fields
+// Error logs a message at error level with an error and structured fields
 // Error logs indicate serious problems that need attention
-// Automatically includes error code, description, and message from the custom error
-func Error(msg string, err errors.Error, fields Fields) {
-	if !checkLoggerInitialized() {
-		return
-	}
-
-	fields = prepareErrorFields(err, fields)
-	zapLogger.Error(msg, fieldsToZapFields(fields)...)
+// Automatically includes error code, description, message, cause chain and app of the error;
+// plain errors are logged as ErrCodeUnknown and a nil error logs the message and fields only
+// With an error throttle configured, repeated identical errors are only logged every Nth time
+// Errors with critical severity also fire the alert hook
+func Error(msg string, err error, fields Fields) {
+	defaultLogger.logError(zap.ErrorLevel, msg, err, fields)
 }
 
-// Fatal logs a message at fatal level with custom error and then exits the application
-// Use sparingly - only for unrecoverable errors that require application termination
-// Automatically includes error code, description, and message from the custom error
-func Fatal(msg string, err errors.Error, fields Fields) {
-	if !checkLoggerInitialized() {
+// LogErr logs an error at the level matching its severity (see errors.SeverityOf):
+// debug, info and warn map to the same zap levels, error and critical to error level
+// Errors without a code are logged as ErrCodeUnknown at error level; nil errors are ignored
+// Critical errors fire the alert hook registered with SetAlertHook
+func LogErr(msg string, err error, fields Fields) {
+	if isNilError(err) {
 		return
 	}
+	defaultLogger.logError(severityLevel(errors.SeverityOf(err)), msg, err, fields)
+}
 
-	fields = prepareErrorFields(err, fields)
-	// Fatal will log the message and then call os.Exit(1)
-	zapLogger.Fatal(msg, fieldsToZapFields(fields)...)
+// Fatal logs a message at fatal level with an error and then exits the application
+// Use sparingly - only for unrecoverable errors that require application termination
+// Adds the same error fields as Error; a nil error still logs the message and exits
+func Fatal(msg string, err error, fields Fields) {
+	defaultLogger.logFatal(msg, err, fields)
 }
 
 // =============================================================================
@@ -242,101 +487,123 @@ func Fatal(msg string, err errors.Error, fields Fields) {
 
 // SetLogLevel dynamically changes the logging level at runtime
 // Valid levels: debug, info, warn/warning, error, fatal
+// The level is changed in place, so the logger and all other options are kept
+// See SetLogLevelFor for temporary changes and SetLoggerLevel for single components
 func SetLogLevel(level string) {
 	if !checkLoggerInitialized() {
 		return
 	}
 
-	// Convert string level to zap's level type
-	var zapLevel zapcore.Level
-	switch strings.ToLower(level) {
-	case "debug":
-		zapLevel = zap.DebugLevel
-	case "info":
-		zapLevel = zap.InfoLevel
-	case "warn", "warning":
-		zapLevel = zap.WarnLevel
-	case "error":
-		zapLevel = zap.ErrorLevel
-	case "fatal":
-		zapLevel = zap.FatalLevel
-	default:
-		zapLevel = zap.InfoLevel // Default to info for invalid levels
-	}
-
-	// Create new logger with updated level using common configuration
-	zapConfig := createZapConfig(zapLevel)
-	newLogger, err := createLoggerWithDefaultFields(zapConfig)
-	if err != nil {
-		return // Keep existing logger if new one fails to build
-	}
+	configMu.Lock()
+	defer configMu.Unlock()
+	setGlobalLevel(parseLevel(level), 0)
+}
 
-	// Replace current logger after ensuring old one is synced
-	zapLogger.Sync()
-	zapLogger = newLogger
+// SetErrorThrottle changes how often repeated identical errors are logged
+// With n > 1 only the 1st, (n+1)th, (2n+1)th... occurrence is emitted; 0 or 1 logs every call
+func SetErrorThrottle(n int) {
+	errorThrottle.Store(int64(n))
+}
+
+// SetAlertHook registers the function notified of every logged error with critical severity,
+// e.g. to page on-call or post to a chat channel; pass nil to disable alerting
+// The hook runs synchronously in the logging goroutine, so slow alerting should be handed off
+func SetAlertHook(hook AlertHook) {
+	alertHookMu.Lock()
+	defer alertHookMu.Unlock()
+	alertHook = hook
 }
 
 // SetFormatter changes the log output format at runtime
-// Supports "json" (structured, machine-readable) and "text"/"console" (human-readable)
+// Supports "json" (structured, machine-readable), "text"/"console" (human-readable)
+// and "pretty"/"local" (colored and aligned for a local terminal)
+// The level and all other options are kept
 func SetFormatter(format string) {
 	if !checkLoggerInitialized() {
 		return
 	}
 
-	// Choose appropriate zap configuration based on desired format
-	var zapConfig zap.Config
-	switch strings.ToLower(format) {
-	case "json":
-		// Production config uses JSON encoding - better for log aggregation
-		zapConfig = zap.NewProductionConfig()
-	case "text", "console":
-		// Development config uses console encoding - better for human reading
-		zapConfig = zap.NewDevelopmentConfig()
-	default:
-		// Default to JSON for unknown formats
-		zapConfig = zap.NewProductionConfig()
+	configMu.Lock()
+	defer configMu.Unlock()
+
+	current := loadState()
+	opts := current.currentOptions()
+	opts.Encoding = parseEncoding(format)
+	if err := swapState(current.defaults, opts, current.level); err != nil {
+		current.zap.Error("Failed to build new logger", zap.Error(err))
 	}
+}
 
-	// Build new logger with updated format
-	newLogger, err := createLoggerWithDefaultFields(zapConfig)
-	if err != nil {
-		zapLogger.Error("Failed to build new logger", zap.Error(err))
-		return // Keep existing logger if new one fails to build
+// SetOptions replaces all logger options at runtime, keeping the default fields
+// Returns the build error and keeps the existing logger if an output cannot be opened
+func SetOptions(opts Options) error {
+	configMu.Lock()
+	defer configMu.Unlock()
+
+	opts = opts.normalized()
+	if err := swapState(loadState().defaults, opts, zap.NewAtomicLevelAt(opts.Level)); err != nil {
+		return err
 	}
+	cancelLevelRevert()
+	return nil
+}
 
-	// Replace current logger after ensuring old one is synced
-	zapLogger.Sync()
-	zapLogger = newLogger
+// GetOptions returns the options of the current logger, including the live level
+func GetOptions() Options {
+	return loadState().currentOptions()
 }
 
 // =============================================================================
 // PUBLIC UTILITY FUNCTIONS
 // =============================================================================
 
-// WithContext returns a logger that can be used with context
-// Note: Zap doesn't have built-in context support like logrus
-// You can extend this to extract values from context if needed
-func WithContext(ctx context.Context) *zap.Logger {
-	if zapLogger == nil {
-		return nil
-	}
-	// Future enhancement: extract correlation IDs, user IDs, etc. from context
-	// and add them as fields to the returned logger
-	return zapLogger
-}
-
 // Sync flushes any buffered log entries to the output
 // Should be called before application shutdown to ensure all logs are written
 func Sync() {
-	if zapLogger != nil {
-		zapLogger.Sync()
+	if logger := currentLogger(); logger != nil {
+		logger.Sync()
 	}
 }
 
 // GetLogger returns the underlying zap logger for advanced usage
 // Use this when you need zap-specific functionality not exposed by this wrapper
 func GetLogger() *zap.Logger {
-	return zapLogger
+	return currentLogger()
+}
+
+// ReplaceCore makes the package-level logger write every entry to core, with the default
+// fields bound, until restore is called; the replaced logger is kept and restored as it was
+// It exists for tests that capture the entries of code logging through the package-level
+// functions, see the logtest package. Tests replacing the core must not run in parallel
+func ReplaceCore(core zapcore.Core) (restore func()) {
+	configMu.Lock()
+	defer configMu.Unlock()
+
+	previous := loadState()
+	logger := zap.New(core).With(fieldsToZapFields(previous.defaults)...)
+	state.Store(&loggerState{
+		zap:       logger,
+		unsampled: logger.Named("logger"),
+		defaults:  previous.defaults,
+		options:   previous.options,
+		level:     previous.level,
+		// No files, so a reconfiguration in between cannot close those of the replaced logger
+	})
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			configMu.Lock()
+			defer configMu.Unlock()
+			replaced := loadState()
+			state.Store(previous)
+			for path, file := range replaced.files { // Opened by a reconfiguration in between
+				if previous.files[path] != file {
+					_ = file.Close()
+				}
+			}
+		})
+	}
 }
 
 // =============================================================================
@@ -353,4 +620,7 @@ func init() {
 		AppVersion:  "unknown",
 		Environment: "unknown",
 	})
+
+	// Report panics recovered by errors.Recover, errors.SafeGo and errors.RecoverMiddleware
+	errors.SetPanicHandler(logPanic)
 }
@@ -2,14 +2,22 @@ package logger
 
 import (
 	"bytes"
+	"context"
+	stderrors "errors"
 	"fmt"
+	"io"
+	"path/filepath"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	errorcodes "github.com/BhaveshKaushal/base-lib/pkg/errors"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
 )
 
 // getZapLevel converts string level to zap level (similar to SetLogLevel logic)
@@ -30,6 +38,46 @@ func getZapLevel(level string) zapcore.Level {
 	}
 }
 
+// setRootLogger replaces the root logger for a test, keeping the current default fields
+// Tests restore the previous logger with restoreLogger
+func setRootLogger(logger *zap.Logger) {
+	state.Store(&loggerState{zap: logger, defaults: loadState().defaults, options: DefaultOptions(), level: zap.NewAtomicLevel()})
+}
+
+// restoreLogger restores the root logger and the throttle and span settings of Initialize
+// when the test ends. The logger is rebuilt through swapState, so files opened by the
+// test are closed as on any reconfiguration
+func restoreLogger(tb testing.TB) {
+	tb.Helper()
+	original := loadState()
+	throttle, spans := errorThrottle.Load(), recordSpanErrors.Load()
+	tb.Cleanup(func() {
+		configMu.Lock()
+		defer configMu.Unlock()
+		errorThrottle.Store(throttle)
+		recordSpanErrors.Store(spans)
+		if err := swapState(original.defaults, original.options, original.level); err != nil {
+			tb.Errorf("restoring the logger: %v", err)
+		}
+		cancelLevelRevert()
+	})
+}
+
+// initializeForTest initializes the logger with the config until the test ends
+// Unless config.Outputs is set, entries are written to app.log in a temporary directory,
+// whose path is returned
+func initializeForTest(tb testing.TB, config LoggerConfig) string {
+	tb.Helper()
+	restoreLogger(tb)
+	var path string
+	if len(config.Outputs) == 0 {
+		path = filepath.Join(tb.TempDir(), "app.log")
+		config.Outputs = []OutputConfig{{Path: path}}
+	}
+	Initialize(config)
+	return path
+}
+
 // TestErrorWithCustomError tests the Error function with custom error objects
 func TestErrorWithCustomError(t *testing.T) {
 	// Create a test logger that captures output
@@ -41,14 +89,10 @@ func TestErrorWithCustomError(t *testing.T) {
 	)
 	testLogger := zap.New(core)
 
-	// Save original logger
-	originalLogger := zapLogger
-	defer func() {
-		zapLogger = originalLogger
-	}()
+	restoreLogger(t)
 
 	// Set test logger
-	zapLogger = testLogger
+	setRootLogger(testLogger)
 
 	// Test with valid custom error
 	testErr := errorcodes.NewErr(errorcodes.ErrCodeDatabase, fmt.Errorf("test error"), "Test error message", "testapp")
@@ -73,14 +117,10 @@ func TestErrorWithInvalidCode(t *testing.T) {
 	)
 	testLogger := zap.New(core)
 
-	// Save original logger
-	originalLogger := zapLogger
-	defer func() {
-		zapLogger = originalLogger
-	}()
+	restoreLogger(t)
 
 	// Set test logger
-	zapLogger = testLogger
+	setRootLogger(testLogger)
 
 	// Create custom error with invalid code
 	testErr := errorcodes.NewErr(errorcodes.Code("invalid"), fmt.Errorf("test error"), "Test error message", "testapp")
@@ -94,11 +134,7 @@ func TestErrorWithInvalidCode(t *testing.T) {
 
 // TestSetLogLevel tests the SetLogLevel function with various inputs
 func TestSetLogLevel(t *testing.T) {
-	// Save original logger
-	originalLogger := zapLogger
-	defer func() {
-		zapLogger = originalLogger
-	}()
+	restoreLogger(t)
 
 	// Initialize logger first to ensure we have a working logger
 	Initialize(LoggerConfig{
@@ -192,8 +228,8 @@ func TestSetLogLevel(t *testing.T) {
 			SetLogLevel(tt.level)
 
 			// Verify the logger is not nil and has a core
-			assert.NotNil(t, zapLogger)
-			assert.NotNil(t, zapLogger.Core())
+			assert.NotNil(t, GetLogger())
+			assert.NotNil(t, GetLogger().Core())
 
 			// Test that the logger can be used without panicking
 			// We can't easily test the actual log level filtering without
@@ -211,14 +247,10 @@ func TestSetLogLevel(t *testing.T) {
 
 // TestSetLogLevelWithNilLogger tests that SetLogLevel handles nil logger gracefully
 func TestSetLogLevelWithNilLogger(t *testing.T) {
-	// Save original logger
-	originalLogger := zapLogger
-	defer func() {
-		zapLogger = originalLogger
-	}()
+	restoreLogger(t)
 
 	// Set logger to nil
-	zapLogger = nil
+	setRootLogger(nil)
 
 	// This should not panic
 	assert.NotPanics(t, func() {
@@ -226,16 +258,12 @@ func TestSetLogLevelWithNilLogger(t *testing.T) {
 	})
 
 	// Verify logger is still nil
-	assert.Nil(t, zapLogger)
+	assert.Nil(t, GetLogger())
 }
 
 // TestSetLogLevelPreservesDefaultFields tests that changing log level preserves default fields
 func TestSetLogLevelPreservesDefaultFields(t *testing.T) {
-	// Save original logger
-	originalLogger := zapLogger
-	defer func() {
-		zapLogger = originalLogger
-	}()
+	restoreLogger(t)
 
 	// Initialize logger with default fields
 	Initialize(LoggerConfig{
@@ -250,17 +278,13 @@ func TestSetLogLevelPreservesDefaultFields(t *testing.T) {
 	})
 
 	// Verify logger is still working
-	assert.NotNil(t, zapLogger)
-	assert.NotNil(t, zapLogger.Core())
+	assert.NotNil(t, GetLogger())
+	assert.NotNil(t, GetLogger().Core())
 }
 
 // TestSetLogLevelMultipleChanges tests that log level can be changed multiple times
 func TestSetLogLevelMultipleChanges(t *testing.T) {
-	// Save original logger
-	originalLogger := zapLogger
-	defer func() {
-		zapLogger = originalLogger
-	}()
+	restoreLogger(t)
 
 	// Test multiple level changes
 	levels := []string{"debug", "info", "warn", "error", "debug", "info"}
@@ -271,23 +295,19 @@ func TestSetLogLevelMultipleChanges(t *testing.T) {
 			SetLogLevel(level)
 
 			// Verify the logger is not nil
-			assert.NotNil(t, zapLogger)
+			assert.NotNil(t, GetLogger())
 
 			// Check that the logger's core level is set correctly
 			// Note: We can't directly access the level from zap.Logger, but we can verify
 			// the logger is working by ensuring it's not nil and can be used
-			assert.NotNil(t, zapLogger.Core())
+			assert.NotNil(t, GetLogger().Core())
 		})
 	}
 }
 
 // TestLogLevelFiltering tests that log levels actually filter messages correctly
 func TestLogLevelFiltering(t *testing.T) {
-	// Save original logger
-	originalLogger := zapLogger
-	defer func() {
-		zapLogger = originalLogger
-	}()
+	restoreLogger(t)
 
 	tests := []struct {
 		name           string
@@ -341,7 +361,7 @@ func TestLogLevelFiltering(t *testing.T) {
 				getZapLevel(tt.level),
 			)
 			testLogger := zap.New(testCore)
-			zapLogger = testLogger
+			setRootLogger(testLogger)
 
 			// Test debug logging
 			Debug("debug message", Fields{"test": "debug"})
@@ -393,11 +413,7 @@ func TestLogLevelFiltering(t *testing.T) {
 // a new logger that doesn't use our test buffer. For actual format validation,
 // see TestSetFormatterActualOutput.
 func TestSetFormatter(t *testing.T) {
-	// Save original logger
-	originalLogger := zapLogger
-	defer func() {
-		zapLogger = originalLogger
-	}()
+	restoreLogger(t)
 
 	// Initialize logger first to ensure we have a working logger
 	Initialize(LoggerConfig{
@@ -461,8 +477,8 @@ func TestSetFormatter(t *testing.T) {
 			})
 
 			// Verify the logger is not nil and has a core
-			assert.NotNil(t, zapLogger)
-			assert.NotNil(t, zapLogger.Core())
+			assert.NotNil(t, GetLogger())
+			assert.NotNil(t, GetLogger().Core())
 
 			// Test that the logger can be used without panicking
 			assert.NotPanics(t, func() {
@@ -474,11 +490,7 @@ func TestSetFormatter(t *testing.T) {
 
 // TestSetFormatterActualOutput tests the actual output format by creating custom loggers
 func TestSetFormatterActualOutput(t *testing.T) {
-	// Save original logger
-	originalLogger := zapLogger
-	defer func() {
-		zapLogger = originalLogger
-	}()
+	restoreLogger(t)
 
 	tests := []struct {
 		name           string
@@ -531,7 +543,7 @@ func TestSetFormatterActualOutput(t *testing.T) {
 			}
 
 			testLogger := zap.New(core)
-			zapLogger = testLogger
+			setRootLogger(testLogger)
 
 			// Log a message
 			Info("test message", Fields{"test": "output", "format": tt.format})
@@ -552,3 +564,534 @@ func TestSetFormatterActualOutput(t *testing.T) {
 		})
 	}
 }
+
+// TestErrorWithMultiErr tests that aggregated errors log every member
+func TestErrorWithMultiErr(t *testing.T) {
+	// Create a test logger that captures output
+	var buf bytes.Buffer
+	core := zapcore.NewCore(
+		zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig()),
+		zapcore.AddSync(&buf),
+		zapcore.DebugLevel,
+	)
+
+	restoreLogger(t)
+	setRootLogger(zap.New(core))
+
+	multiErr := errorcodes.NewMultiErr("testapp").Append(
+		errorcodes.NewErrDefault(errorcodes.ErrCodeMissingField, "name is required", "testapp"),
+		errorcodes.NewErrDefault(errorcodes.ErrCodeInvalidFormat, "email is invalid", "testapp"),
+	)
+	Error("validation failed", multiErr, nil)
+
+	output := buf.String()
+	assert.Contains(t, output, `"errors":[`)
+	assert.Contains(t, output, string(errorcodes.ErrCodeMissingField))
+	assert.Contains(t, output, "email is invalid")
+	assert.Contains(t, output, `"code":"`+string(errorcodes.ErrCodeValidation)+`"`)
+	assert.Contains(t, output, `"category":"validation"`)
+}
+
+// TestErrorThrottle tests that repeated identical errors are only emitted every Nth time
+func TestErrorThrottle(t *testing.T) {
+	var buf bytes.Buffer
+	core := zapcore.NewCore(
+		zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig()),
+		zapcore.AddSync(&buf),
+		zapcore.DebugLevel,
+	)
+
+	restoreLogger(t)
+	defer errorTracker.Reset()
+	setRootLogger(zap.New(core))
+	SetErrorThrottle(3)
+
+	newErr := func(id int) *errorcodes.Err {
+		return errorcodes.NewErrDefault(errorcodes.ErrCodeDBQuery, fmt.Sprintf("query %d failed", id), "testapp")
+	}
+	for i := 1; i <= 7; i++ {
+		Error("query failed", newErr(i), nil)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Len(t, lines, 3, "occurrences 1, 4 and 7 should be emitted")
+	assert.Contains(t, lines[0], `"occurrences":1`)
+	assert.Contains(t, lines[1], `"occurrences":4`)
+	assert.Contains(t, lines[2], `"occurrences":7`)
+
+	// A different error is throttled independently
+	buf.Reset()
+	Error("timeout", errorcodes.NewErrDefault(errorcodes.ErrCodeTimeout, "timed out", "testapp"), nil)
+	assert.Contains(t, buf.String(), `"occurrences":1`)
+}
+
+// TestRecoveredPanicIsLogged tests that panics recovered by the errors package are logged
+func TestRecoveredPanicIsLogged(t *testing.T) {
+	var buf bytes.Buffer
+	core := zapcore.NewCore(
+		zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig()),
+		zapcore.AddSync(&buf),
+		zapcore.DebugLevel,
+	)
+
+	restoreLogger(t)
+	setRootLogger(zap.New(core))
+
+	run := func() (err error) {
+		defer errorcodes.Recover(&err)
+		panic("worker crashed")
+	}
+	assert.Error(t, run())
+
+	output := buf.String()
+	assert.Contains(t, output, "Recovered from panic")
+	assert.Contains(t, output, `"code":"`+string(errorcodes.ErrCodeInternal)+`"`)
+	assert.Contains(t, output, `"panic":"worker crashed"`)
+	assert.Contains(t, output, `"stack":"goroutine`)
+}
+
+// TestLogErrLevels tests that LogErr picks the log level from the error severity
+func TestLogErrLevels(t *testing.T) {
+	var buf bytes.Buffer
+	core := zapcore.NewCore(
+		zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig()),
+		zapcore.AddSync(&buf),
+		zapcore.DebugLevel,
+	)
+
+	restoreLogger(t)
+	setRootLogger(zap.New(core))
+
+	tests := []struct {
+		name          string
+		err           error
+		expectedLevel string
+		severity      string
+	}{
+		{
+			name:          "info code",
+			err:           errorcodes.NewErrDefault(errorcodes.ErrCodeDBNotFound, "user not found", "testapp"),
+			expectedLevel: "info",
+			severity:      "info",
+		},
+		{
+			name:          "warn category",
+			err:           errorcodes.NewErrDefault(errorcodes.ErrCodeMissingField, "name is required", "testapp"),
+			expectedLevel: "warn",
+			severity:      "warn",
+		},
+		{
+			name:          "error code",
+			err:           errorcodes.NewErrDefault(errorcodes.ErrCodeDBQuery, "query failed", "testapp"),
+			expectedLevel: "error",
+			severity:      "error",
+		},
+		{
+			name: "per-error override",
+			err: errorcodes.NewErrDefault(errorcodes.ErrCodeDBQuery, "cache miss", "testapp").
+				WithSeverity(errorcodes.SeverityDebug),
+			expectedLevel: "debug",
+			severity:      "debug",
+		},
+		{
+			name:          "critical code",
+			err:           errorcodes.NewErrDefault(errorcodes.ErrCodeInitialization, "startup failed", "testapp"),
+			expectedLevel: "error",
+			severity:      "critical",
+		},
+		{
+			name:          "plain error",
+			err:           stderrors.New("boom"),
+			expectedLevel: "error",
+			severity:      "error",
+		},
+		{
+			name:          "wrapped coded error",
+			err:           fmt.Errorf("handler: %w", errorcodes.NewErrDefault(errorcodes.ErrCodeNotFound, "order missing", "testapp")),
+			expectedLevel: "info",
+			severity:      "info",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf.Reset()
+			LogErr("request failed", tt.err, nil)
+
+			output := buf.String()
+			assert.Contains(t, output, `"level":"`+tt.expectedLevel+`"`)
+			assert.Contains(t, output, `"severity":"`+tt.severity+`"`)
+			assert.Contains(t, output, `"code":"`+string(errorcodes.CodeOf(tt.err))+`"`)
+		})
+	}
+
+	buf.Reset()
+	LogErr("nothing happened", nil, nil)
+	assert.Empty(t, buf.String(), "nil errors should not be logged")
+}
+
+// TestAlertHook tests that only critical errors fire the alert hook
+func TestAlertHook(t *testing.T) {
+	var buf bytes.Buffer
+	core := zapcore.NewCore(
+		zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig()),
+		zapcore.AddSync(&buf),
+		zapcore.DebugLevel,
+	)
+
+	restoreLogger(t)
+	defer SetAlertHook(nil)
+	setRootLogger(zap.New(core))
+
+	var alerts []string
+	SetAlertHook(func(msg string, err error, fields Fields) {
+		alerts = append(alerts, msg)
+		assert.Equal(t, errorcodes.SeverityCritical, fields["severity"])
+	})
+
+	critical := errorcodes.NewErrDefault(errorcodes.ErrCodeInitialization, "startup failed", "testapp")
+	LogErr("startup failed", critical, nil)
+	Error("startup failed again", critical, nil)
+	LogErr("query failed", errorcodes.NewErrDefault(errorcodes.ErrCodeDBQuery, "query failed", "testapp"), nil)
+	LogErr("escalated", errorcodes.NewErrDefault(errorcodes.ErrCodeDBQuery, "replica lost", "testapp").
+		WithSeverity(errorcodes.SeverityCritical), nil)
+
+	assert.Equal(t, []string{"startup failed", "startup failed again", "escalated"}, alerts)
+
+	// A panicking hook must not break logging
+	SetAlertHook(func(msg string, err error, fields Fields) { panic("pager down") })
+	assert.NotPanics(t, func() { LogErr("startup failed", critical, nil) })
+}
+
+// TestErrorDoesNotMutateFields tests that the caller's fields map is left unchanged
+func TestErrorDoesNotMutateFields(t *testing.T) {
+	var buf bytes.Buffer
+	core := zapcore.NewCore(
+		zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig()),
+		zapcore.AddSync(&buf),
+		zapcore.DebugLevel,
+	)
+
+	restoreLogger(t)
+	setRootLogger(zap.New(core))
+
+	fields := Fields{"order_id": "ord-1"}
+	Error("query failed", errorcodes.NewErrDefault(errorcodes.ErrCodeDBQuery, "query failed", "testapp"), fields)
+
+	assert.Contains(t, buf.String(), `"code":"`+string(errorcodes.ErrCodeDBQuery)+`"`)
+	assert.Equal(t, Fields{"order_id": "ord-1"}, fields)
+}
+
+// TestErrorFields tests the error fields written for coded, plain and nil errors
+func TestErrorFields(t *testing.T) {
+	core, logs := observer.New(zap.DebugLevel)
+	restoreLogger(t)
+	setRootLogger(zap.New(core))
+
+	dbErr := errorcodes.NewErr(errorcodes.ErrCodeDBQuery, fmt.Errorf("connection reset"), "query failed", "orders")
+	var nilErr *errorcodes.Err
+
+	tests := []struct {
+		name     string
+		err      error
+		expected map[string]interface{} // Expected fields, nil values must be absent
+	}{
+		{
+			name: "coded error",
+			err:  dbErr,
+			expected: map[string]interface{}{
+				"code":          string(errorcodes.ErrCodeDBQuery),
+				"error_message": "query failed",
+				"error_cause":   "connection reset",
+				"error_chain":   []interface{}{"connection reset"},
+				"error_app":     "orders",
+				"app":           nil,
+			},
+		},
+		{
+			name: "wrapped chain",
+			err:  fmt.Errorf("handling request: %w", errorcodes.WrapCode(dbErr, errorcodes.ErrCodeDatabase, "loading order")),
+			expected: map[string]interface{}{
+				"code":          string(errorcodes.ErrCodeDatabase),
+				"error_message": "loading order",
+				"error_cause":   "connection reset",
+				"error_chain": []interface{}{
+					"handling request: loading order: connection reset",
+					"loading order: connection reset",
+					"connection reset",
+				},
+				"error_app": "orders",
+			},
+		},
+		{
+			name: "nil underlying error",
+			err:  errorcodes.NewErr(errorcodes.ErrCodeConfig, nil, "config file missing", "reader"),
+			expected: map[string]interface{}{
+				"code":          string(errorcodes.ErrCodeConfig),
+				"error_message": "config file missing",
+				"error_cause":   nil,
+				"error_chain":   []interface{}{"config file missing"},
+				"error_app":     "reader",
+			},
+		},
+		{
+			name: "plain error",
+			err:  stderrors.New("disk full"),
+			expected: map[string]interface{}{
+				"code":          string(errorcodes.ErrCodeUnknown),
+				"error_message": "disk full",
+				"error_chain":   []interface{}{"disk full"},
+				"error_app":     nil,
+			},
+		},
+		{
+			name:     "nil error",
+			err:      nil,
+			expected: map[string]interface{}{"code": nil, "error_chain": nil, "order_id": "ord-1"},
+		},
+		{
+			name:     "typed nil error",
+			err:      nilErr,
+			expected: map[string]interface{}{"code": nil, "error_chain": nil, "order_id": "ord-1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logs.TakeAll()
+			require.NotPanics(t, func() { Error("failed", tt.err, Fields{"order_id": "ord-1"}) })
+
+			entries := logs.TakeAll()
+			require.Len(t, entries, 1)
+			assert.Equal(t, zap.ErrorLevel, entries[0].Level)
+			fields := entries[0].ContextMap()
+			for key, expected := range tt.expected {
+				if expected == nil {
+					assert.NotContains(t, fields, key)
+					continue
+				}
+				assert.EqualValues(t, expected, fields[key], key)
+			}
+		})
+	}
+}
+
+// TestFatalNilSafe tests that Fatal logs nil and plain errors before exiting
+func TestFatalNilSafe(t *testing.T) {
+	core, logs := observer.New(zap.DebugLevel)
+	restoreLogger(t)
+	setRootLogger(zap.New(core, zap.WithFatalHook(zapcore.WriteThenPanic)))
+
+	var nilErr *errorcodes.Err
+	assert.Panics(t, func() { Fatal("no error", nil, nil) })
+	assert.Panics(t, func() { Fatal("typed nil error", nilErr, nil) })
+	assert.Panics(t, func() { Fatal("plain error", stderrors.New("disk full"), nil) })
+
+	entries := logs.All()
+	require.Len(t, entries, 3)
+	assert.NotContains(t, entries[0].ContextMap(), "code")
+	assert.NotContains(t, entries[1].ContextMap(), "code")
+	assert.EqualValues(t, errorcodes.ErrCodeUnknown, entries[2].ContextMap()["code"])
+}
+
+// TestCallerAnnotation tests that every logging function reports the application's call site
+func TestCallerAnnotation(t *testing.T) {
+	core, logs := observer.New(zap.DebugLevel)
+	restoreLogger(t)
+	setRootLogger(zap.New(core, zap.AddCaller()))
+
+	dbErr := errorcodes.NewErrDefault(errorcodes.ErrCodeDBQuery, "query failed", "testapp")
+	ctx := context.Background()
+	calls := map[string]func(){
+		"Info":           func() { Info("entry", nil) },
+		"Default().Info": func() { Default().Info("entry", nil) },
+		"Named().Warn":   func() { Named("worker").With(Fields{"id": 1}).Warn("entry", nil) },
+		"InfoCtx":        func() { InfoCtx(ctx, "entry", nil) },
+		"Error":          func() { Error("entry", dbErr, nil) },
+		"Default().Error": func() {
+			Default().Error("entry", dbErr, nil)
+		},
+		"LogErr":    func() { LogErr("entry", dbErr, nil) },
+		"ErrorCtx":  func() { ErrorCtx(ctx, "entry", dbErr, nil) },
+		"LogErrCtx": func() { LogErrCtx(ctx, "entry", dbErr, nil) },
+		"Infow":     func() { Infow("entry", Int("id", 1)) },
+		"Warnw":     func() { Default().Warnw("entry") },
+		"Zap":       func() { Default().Zap().Info("entry") },
+	}
+
+	for name, call := range calls {
+		t.Run(name, func(t *testing.T) {
+			logs.TakeAll()
+			call()
+			entries := logs.TakeAll()
+			require.Len(t, entries, 1)
+			require.True(t, entries[0].Caller.Defined)
+			assert.Equal(t, "logger_test.go", filepath.Base(entries[0].Caller.File), entries[0].Caller.String())
+			assert.Contains(t, entries[0].Caller.Function, "TestCallerAnnotation", "the caller is the closure in this test")
+		})
+	}
+}
+
+// TestConcurrentReconfiguration logs from many goroutines while the logger is reconfigured
+// Run with -race to detect unsynchronized access to the logger state
+func TestConcurrentReconfiguration(t *testing.T) {
+	restoreLogger(t)
+	defer errorTracker.Reset()
+
+	// Entries are logged at debug level, and errors are throttled, so the hammering stays quiet
+	config := LoggerConfig{AppName: "race-app", AppVersion: "1.0.0", Environment: "test", ErrorThrottle: 100000}
+	Initialize(config)
+
+	const iterations = 200
+	shared := Fields{"shared": "value"}
+	worker := Named("worker").With(Fields{"worker_id": 1})
+	ctx := WithRequestID(context.Background(), "req-1")
+	err := errorcodes.NewErrDefault(errorcodes.ErrCodeDBQuery, "query failed", "race-app")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < iterations; j++ {
+				Debug("debug message", shared)
+				worker.Debug("worker message", shared)
+				DebugCtx(ctx, "context message", shared)
+				Error("error message", err, shared)
+				worker.LogErr("worker error", err, shared)
+				_ = GetLogger()
+				_ = WithContext(ctx)
+				Sync()
+			}
+		}()
+	}
+
+	levels := []string{"info", "warn", "error"}
+	formats := []string{"json", "console"}
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for j := 0; j < iterations; j++ {
+			SetLogLevel(levels[j%len(levels)])
+			SetFormatter(formats[j%len(formats)])
+			SetErrorThrottle(100000 + j)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for j := 0; j < iterations; j++ {
+			Initialize(config)
+		}
+	}()
+	wg.Wait()
+
+	assert.Equal(t, Fields{"shared": "value"}, shared)
+	assert.NotNil(t, GetLogger())
+	assert.Equal(t, "race-app", loadState().defaults["app_name"])
+}
+
+// TestReplaceCore tests capturing the root logger and restoring it
+func TestReplaceCore(t *testing.T) {
+	logPath := initializeForTest(t, LoggerConfig{AppName: "test-app", Environment: "test"})
+	before := loadState()
+
+	var buf bytes.Buffer
+	restore := ReplaceCore(zapcore.NewCore(
+		zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig()), zapcore.AddSync(&buf), zapcore.DebugLevel))
+	Debug("captured debug", nil)
+	assert.Contains(t, buf.String(), `"msg":"captured debug"`)
+	assert.Contains(t, buf.String(), `"app_name":"test-app"`, "default fields are bound")
+
+	// Files opened by a reconfiguration while replaced are closed on restore
+	path := filepath.Join(t.TempDir(), "interim.log")
+	require.NoError(t, SetOptions(Options{Outputs: []OutputConfig{{Path: path}}}))
+	interim := loadState().files[path]
+	require.NotNil(t, interim)
+
+	restore()
+	restore() // Restoring twice is harmless
+	assert.Same(t, before, loadState())
+	Info("written after restore", nil)
+	assert.Contains(t, readLog(t, logPath), "written after restore", "the files of the restored logger stay open")
+	_, err := interim.Write([]byte("late entry\n"))
+	assert.Error(t, err)
+}
+
+// =============================================================================
+// BENCHMARKS
+// =============================================================================
+
+// benchmarkLogger initializes the logger as applications do, through Initialize with the
+// given options, writing to io.Discard instead of stdout
+func benchmarkLogger(b *testing.B, opts Options) {
+	restoreLogger(b)
+	stdout := stdoutSink
+	stdoutSink = zapcore.AddSync(io.Discard)
+	b.Cleanup(func() { stdoutSink = stdout }) // Runs before the logger is restored
+	Initialize(LoggerConfig{AppName: "bench-app", Environment: "production", Options: &opts})
+	b.ReportAllocs()
+	b.ResetTimer()
+}
+
+// benchmarkPipelines runs a benchmark against the pipeline DefaultOptions builds, with level
+// check, sampling, masking and hooks, and against the same pipeline without sampling
+// Sampling drops most of the repeated benchmark entries before they are encoded, so only
+// the unsampled run measures the cost of writing an entry
+func benchmarkPipelines(b *testing.B, log func(i int)) {
+	unsampled := DefaultOptions()
+	unsampled.Sampling = nil
+	for _, pipeline := range []struct {
+		name string
+		opts Options
+	}{
+		{name: "default", opts: DefaultOptions()},
+		{name: "unsampled", opts: unsampled},
+	} {
+		b.Run(pipeline.name, func(b *testing.B) {
+			benchmarkLogger(b, pipeline.opts)
+			for i := 0; i < b.N; i++ {
+				log(i)
+			}
+		})
+	}
+}
+
+// BenchmarkInfoFields measures the map-based API
+func BenchmarkInfoFields(b *testing.B) {
+	benchmarkPipelines(b, func(int) {
+		Info("request handled", Fields{
+			"method":  "GET",
+			"status":  200,
+			"elapsed": time.Millisecond,
+			"code":    errorcodes.ErrCodeTimeout,
+		})
+	})
+}
+
+// BenchmarkInfow measures the typed field API with the same fields
+func BenchmarkInfow(b *testing.B) {
+	benchmarkPipelines(b, func(int) {
+		Infow("request handled",
+			Str("method", "GET"),
+			Int("status", 200),
+			Dur("elapsed", time.Millisecond),
+			Code(errorcodes.ErrCodeTimeout),
+		)
+	})
+}
+
+// BenchmarkDebugFieldsDisabled measures the map-based API below the logger level
+func BenchmarkDebugFieldsDisabled(b *testing.B) {
+	benchmarkLogger(b, DefaultOptions())
+	for i := 0; i < b.N; i++ {
+		Debug("cache miss", Fields{"key": "user:1", "attempt": i})
+	}
+}
+
+// BenchmarkDebugwDisabled measures the typed field API below the logger level
+func BenchmarkDebugwDisabled(b *testing.B) {
+	benchmarkLogger(b, DefaultOptions())
+	for i := 0; i < b.N; i++ {
+		Debugw("cache miss", Str("key", "user:1"), Int("attempt", i))
+	}
+}
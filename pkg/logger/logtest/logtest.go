@@ -0,0 +1,174 @@
+// Package logtest captures log entries in memory so tests can assert on what code logs
+//
+// New returns a Recorder scoped to one test: entries written through its Logger, or
+// through the *Ctx functions with its Context, are only seen by that recorder, so parallel
+// tests do not see each other's entries. Global captures the package-level logger instead,
+// for code that calls logger.Info and friends directly; such tests must not run in parallel
+package logtest
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/BhaveshKaushal/base-lib/pkg/errors"
+	"github.com/BhaveshKaushal/base-lib/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// =============================================================================
+// ENTRIES
+// =============================================================================
+
+// Entry is one captured log entry
+type Entry struct {
+	Level      zapcore.Level
+	Message    string
+	LoggerName string // Dotted component name, empty for the default logger
+	Time       time.Time
+	Fields     logger.Fields // All fields, including bound and default fields
+}
+
+// Code returns the error code of an entry written by Error, LogErr or Fatal, empty otherwise
+func (e Entry) Code() errors.Code {
+	switch code := e.Fields["code"].(type) {
+	case errors.Code:
+		return code
+	case string:
+		return errors.Code(code)
+	}
+	return ""
+}
+
+// toEntry converts an entry captured by the observer core
+func toEntry(logged observer.LoggedEntry) Entry {
+	return Entry{
+		Level:      logged.Level,
+		Message:    logged.Message,
+		LoggerName: logged.LoggerName,
+		Time:       logged.Time,
+		Fields:     logger.Fields(logged.ContextMap()),
+	}
+}
+
+// =============================================================================
+// RECORDER
+// =============================================================================
+
+// Recorder holds the entries captured for one test
+type Recorder struct {
+	logs   *observer.ObservedLogs
+	logger logger.Logger
+}
+
+// New returns a recorder capturing entries of every level written through its Logger
+// or Context; entries of other tests and of the package-level logger are not captured
+func New(t testing.TB) *Recorder {
+	t.Helper()
+	core, logs := observer.New(zapcore.DebugLevel)
+	return &Recorder{logs: logs, logger: logger.WithCore(core)}
+}
+
+// Global returns a recorder capturing every entry of the package-level logger, including
+// the default fields, until the test ends; the configured logger is restored afterwards
+// Tests using Global must not run in parallel with other tests that log
+func Global(t testing.TB) *Recorder {
+	t.Helper()
+	core, logs := observer.New(zapcore.DebugLevel)
+	t.Cleanup(logger.ReplaceCore(core))
+	return &Recorder{logs: logs, logger: logger.Default()}
+}
+
+// Logger returns the logger whose entries the recorder captures, to inject into the code under test
+func (r *Recorder) Logger() logger.Logger {
+	return r.logger
+}
+
+// Context returns a copy of ctx carrying the recorder's logger, so the *Ctx functions
+// called with it are captured
+func (r *Recorder) Context(ctx context.Context) context.Context {
+	return logger.ContextWithLogger(ctx, r.logger)
+}
+
+// Entries returns the captured entries in the order they were written
+func (r *Recorder) Entries() []Entry {
+	return convert(r.logs.All())
+}
+
+// FilterByLevel returns the captured entries of a level
+func (r *Recorder) FilterByLevel(level zapcore.Level) []Entry {
+	return convert(r.logs.FilterLevelExact(level).All())
+}
+
+// FilterByMessage returns the captured entries with the given message
+func (r *Recorder) FilterByMessage(msg string) []Entry {
+	return convert(r.logs.FilterMessage(msg).All())
+}
+
+// FilterByCode returns the captured error entries with the given error code
+func (r *Recorder) FilterByCode(code errors.Code) []Entry {
+	var entries []Entry
+	for _, entry := range r.Entries() {
+		if entry.Code() == code {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+// Len returns the number of captured entries
+func (r *Recorder) Len() int {
+	return r.logs.Len()
+}
+
+// Reset discards the captured entries, e.g. between the steps of a test
+func (r *Recorder) Reset() {
+	r.logs.TakeAll()
+}
+
+// AssertLogged asserts that an entry with the level and message was captured that has
+// at least the given fields; numbers compare by value, so 42 matches an int64 field
+func (r *Recorder) AssertLogged(t testing.TB, level zapcore.Level, msg string, fields logger.Fields) bool {
+	t.Helper()
+	candidates := r.logs.FilterLevelExact(level).FilterMessage(msg).All()
+	for _, logged := range candidates {
+		if hasFields(toEntry(logged).Fields, fields) {
+			return true
+		}
+	}
+	if len(candidates) == 0 {
+		return assert.Fail(t, fmt.Sprintf("no %s entry %q was logged", level, msg), "captured entries: %v", r.Entries())
+	}
+	return assert.Fail(t, fmt.Sprintf("no %s entry %q was logged with fields %v", level, msg, fields),
+		"entries with this message: %v", convert(candidates))
+}
+
+// AssertNotLogged asserts that no entry with the level and message was captured
+func (r *Recorder) AssertNotLogged(t testing.TB, level zapcore.Level, msg string) bool {
+	t.Helper()
+	matches := r.logs.FilterLevelExact(level).FilterMessage(msg).All()
+	return assert.Empty(t, convert(matches), "no %s entry %q should have been logged", level, msg)
+}
+
+// hasFields reports whether actual contains every expected field with an equal value
+func hasFields(actual, expected logger.Fields) bool {
+	for key, value := range expected {
+		got, ok := actual[key]
+		if !ok || !assert.ObjectsAreEqualValues(value, got) {
+			return false
+		}
+	}
+	return true
+}
+
+// convert converts entries captured by the observer core
+func convert(logged []observer.LoggedEntry) []Entry {
+	entries := make([]Entry, 0, len(logged))
+	for _, entry := range logged {
+		entries = append(entries, toEntry(entry))
+	}
+	return entries
+}
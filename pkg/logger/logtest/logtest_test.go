@@ -0,0 +1,146 @@
+package logtest
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/BhaveshKaushal/base-lib/pkg/errors"
+	"github.com/BhaveshKaushal/base-lib/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// recordingT captures assertion failures instead of failing the test
+type recordingT struct {
+	testing.TB
+	failures []string
+}
+
+// Errorf records a failure
+func (r *recordingT) Errorf(format string, args ...interface{}) {
+	r.failures = append(r.failures, fmt.Sprintf(format, args...))
+}
+
+// Helper does nothing, the embedded TB is only set for Cleanup
+func (r *recordingT) Helper() {}
+
+// TestRecorder tests capturing and filtering entries written through the recorder's logger
+func TestRecorder(t *testing.T) {
+	rec := New(t)
+	log := rec.Logger().Named("payments").With(logger.Fields{"tenant": "acme"})
+
+	log.Debug("cache miss", logger.Fields{"key": "user:1"})
+	log.Info("payment accepted", logger.Fields{"amount": 42, "currency": "EUR"})
+	log.Error("payment failed", errors.NewErrDefault(errors.ErrCodeDBQuery, "query failed", "test-app"), nil)
+	log.LogErr("payment not found", errors.NewErrDefault(errors.ErrCodeNotFound, "no payment", "test-app"), nil)
+
+	require.Equal(t, 4, rec.Len())
+	rec.AssertLogged(t, zap.DebugLevel, "cache miss", logger.Fields{"key": "user:1"})
+	rec.AssertLogged(t, zap.InfoLevel, "payment accepted", logger.Fields{"amount": 42, "tenant": "acme"})
+	rec.AssertLogged(t, zap.ErrorLevel, "payment failed", logger.Fields{"code": errors.ErrCodeDBQuery})
+	rec.AssertNotLogged(t, zap.WarnLevel, "payment accepted")
+
+	entries := rec.Entries()
+	assert.Equal(t, "payments", entries[0].LoggerName)
+	assert.Equal(t, "cache miss", entries[0].Message)
+
+	failed := rec.FilterByCode(errors.ErrCodeDBQuery)
+	require.Len(t, failed, 1)
+	assert.Equal(t, "payment failed", failed[0].Message)
+	assert.Equal(t, errors.ErrCodeDBQuery, failed[0].Code())
+
+	notFound := rec.FilterByCode(errors.ErrCodeNotFound)
+	require.Len(t, notFound, 1)
+	assert.Equal(t, zap.InfoLevel, notFound[0].Level, "LogErr uses the severity of the code")
+
+	assert.Len(t, rec.FilterByLevel(zap.InfoLevel), 2)
+	assert.Len(t, rec.FilterByMessage("cache miss"), 1)
+	assert.Empty(t, rec.FilterByCode(errors.ErrCodeUnknown))
+
+	rec.Reset()
+	assert.Zero(t, rec.Len())
+	assert.Empty(t, rec.Entries())
+}
+
+// TestAssertLoggedFailures tests the failures reported by the assertions
+func TestAssertLoggedFailures(t *testing.T) {
+	rec := New(t)
+	rec.Logger().Info("user created", logger.Fields{"user": "alice"})
+
+	tests := []struct {
+		name    string
+		assert  func(r *recordingT) bool
+		failure string
+	}{
+		{
+			name:    "missing message",
+			assert:  func(r *recordingT) bool { return rec.AssertLogged(r, zap.InfoLevel, "user deleted", nil) },
+			failure: `no info entry "user deleted" was logged`,
+		},
+		{
+			name:    "wrong level",
+			assert:  func(r *recordingT) bool { return rec.AssertLogged(r, zap.WarnLevel, "user created", nil) },
+			failure: `no warn entry "user created" was logged`,
+		},
+		{
+			name: "different field",
+			assert: func(r *recordingT) bool {
+				return rec.AssertLogged(r, zap.InfoLevel, "user created", logger.Fields{"user": "bob"})
+			},
+			failure: `was logged with fields`,
+		},
+		{
+			name:    "unexpected entry",
+			assert:  func(r *recordingT) bool { return rec.AssertNotLogged(r, zap.InfoLevel, "user created") },
+			failure: `should have been logged`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &recordingT{TB: t}
+			assert.False(t, tt.assert(r))
+			require.Len(t, r.failures, 1)
+			assert.Contains(t, r.failures[0], tt.failure)
+		})
+	}
+}
+
+// TestParallelRecorders tests that recorders of parallel tests only see their own entries
+func TestParallelRecorders(t *testing.T) {
+	for i := 0; i < 4; i++ {
+		i := i
+		t.Run(fmt.Sprintf("test-%d", i), func(t *testing.T) {
+			t.Parallel()
+			rec := New(t)
+			ctx := rec.Context(logger.WithRequestID(context.Background(), fmt.Sprintf("req-%d", i)))
+
+			for n := 0; n < 50; n++ {
+				logger.InfoCtx(ctx, "handled request", logger.Fields{"n": n})
+			}
+			assert.Equal(t, 50, rec.Len())
+			for _, entry := range rec.Entries() {
+				assert.Equal(t, fmt.Sprintf("req-%d", i), entry.Fields[logger.RequestIDKey])
+			}
+		})
+	}
+}
+
+// TestGlobal tests capturing the package-level logger and restoring it afterwards
+func TestGlobal(t *testing.T) {
+	before := logger.GetLogger()
+
+	t.Run("capture", func(t *testing.T) {
+		rec := Global(t)
+		logger.Debug("debug is captured below the logger level", nil)
+		logger.Named("jobs").Warn("job retried", logger.Fields{"attempt": 2})
+
+		rec.AssertLogged(t, zap.DebugLevel, "debug is captured below the logger level", nil)
+		rec.AssertLogged(t, zap.WarnLevel, "job retried", logger.Fields{"attempt": 2, "app_name": "unknown"})
+		assert.Equal(t, "jobs", rec.FilterByMessage("job retried")[0].LoggerName)
+	})
+
+	assert.Same(t, before, logger.GetLogger(), "the logger is restored when the test ends")
+}
@@ -0,0 +1,583 @@
+package logger
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding"
+	"encoding/hex"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// =============================================================================
+// MASKING
+// =============================================================================
+
+// MaskedKey is the field added to every entry in which a value was masked
+const MaskedKey = "masked"
+
+// redacted replaces masked values in MaskRedact mode
+const redacted = "[REDACTED]"
+
+// MaskMode selects how masked values are replaced
+type MaskMode string
+
+// Supported masking modes
+const (
+	MaskRedact MaskMode = "redact" // Replace values with "[REDACTED]"
+	MaskHash   MaskMode = "hash"   // Replace values with a salted hash, so equal values can still be correlated
+)
+
+// Detector finds sensitive values inside strings, e.g. tokens or card numbers
+type Detector struct {
+	Name     string                  // Name of the detected kind of value, for documentation
+	Pattern  *regexp.Regexp          // Candidates for sensitive values
+	Validate func(match string) bool // Confirms a candidate, e.g. with a checksum; nil accepts every match
+}
+
+// Built-in detectors, all enabled by DefaultMaskingConfig
+var (
+	// JWTDetector finds JSON Web Tokens: three base64url parts whose first two encode JSON objects
+	JWTDetector = Detector{
+		Name:    "jwt",
+		Pattern: regexp.MustCompile(`eyJ[A-Za-z0-9_-]{4,}\.eyJ[A-Za-z0-9_-]{4,}\.[A-Za-z0-9_-]*`),
+	}
+
+	// CardDetector finds payment card numbers of 13 to 19 digits, optionally grouped with
+	// spaces or dashes, that pass the Luhn checksum
+	CardDetector = Detector{
+		Name:     "card",
+		Pattern:  regexp.MustCompile(`\b\d(?:[ -]?\d){12,18}\b`),
+		Validate: luhnValid,
+	}
+
+	// EmailDetector finds email addresses
+	EmailDetector = Detector{
+		Name:    "email",
+		Pattern: regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`),
+	}
+)
+
+// MaskingConfig controls which field values are masked before entries are encoded
+// A field whose key matches one of the Keys is masked as a whole, including nested values;
+// other string values are searched with the Detectors. Keys of nested maps and struct
+// fields (by their JSON names) are checked as well
+type MaskingConfig struct {
+	Keys      []string   // Case-insensitive regular expressions for sensitive field names
+	Detectors []Detector // Detectors for sensitive values inside strings
+	Mode      MaskMode   // MaskRedact or MaskHash; empty means MaskRedact
+	Salt      string     // Salt for MaskHash, so hashes cannot be looked up in precomputed tables
+}
+
+// DefaultMaskingConfig returns the masking used by DefaultOptions: passwords, secrets,
+// tokens, API keys, authorization headers, cookies, social security and card numbers by
+// key, and JWTs, card numbers and email addresses anywhere in string values
+func DefaultMaskingConfig() MaskingConfig {
+	return MaskingConfig{
+		Keys: []string{
+			`passw(or)?d`, `secret`, `token`, `api[_-]?key`, `private[_-]?key`,
+			`authorization`, `cookie`, `ssn`, `social[_-]?security`,
+			`^card$`, `card[_-]?(number|num|no)`, `credit[_-]?card`, `cvv`, `cvc`,
+		},
+		Detectors: []Detector{JWTDetector, CardDetector, EmailDetector},
+		Mode:      MaskRedact,
+	}
+}
+
+// normalized returns a copy of the config with the slices copied and the mode defaulted
+func (c MaskingConfig) normalized() MaskingConfig {
+	c.Keys = append([]string(nil), c.Keys...)
+	c.Detectors = append([]Detector(nil), c.Detectors...)
+	if c.Mode == "" {
+		c.Mode = MaskRedact
+	}
+	return c
+}
+
+// maxCachedKeys bounds the keys whose sensitivity a masker remembers, so loggers
+// writing generated keys do not grow the cache forever
+const maxCachedKeys = 1024
+
+// masker applies a compiled MaskingConfig
+type masker struct {
+	keys      *regexp.Regexp // All key patterns combined, nil if there are none
+	detectors []Detector
+	mode      MaskMode
+	salt      string
+
+	keysMu   sync.RWMutex
+	keyCache map[string]bool // Results of matching keys, as the same few keys are logged over and over
+}
+
+// newMasker compiles the key patterns of a masking config
+func newMasker(config MaskingConfig) (*masker, error) {
+	m := &masker{detectors: config.Detectors, mode: config.Mode, salt: config.Salt, keyCache: make(map[string]bool)}
+	if m.mode != MaskRedact && m.mode != MaskHash {
+		return nil, fmt.Errorf("unknown masking mode %q", config.Mode)
+	}
+	for _, detector := range m.detectors {
+		if detector.Pattern == nil {
+			return nil, fmt.Errorf("masking detector %q has no pattern", detector.Name)
+		}
+	}
+	if len(config.Keys) > 0 {
+		keys, err := regexp.Compile(`(?i)(?:` + strings.Join(config.Keys, `)|(?:`) + `)`)
+		if err != nil {
+			return nil, fmt.Errorf("compiling masking key patterns: %w", err)
+		}
+		m.keys = keys
+	}
+	return m, nil
+}
+
+// sensitiveKey reports whether values of the key are masked as a whole
+func (m *masker) sensitiveKey(key string) bool {
+	if m.keys == nil {
+		return false
+	}
+	m.keysMu.RLock()
+	sensitive, ok := m.keyCache[key]
+	m.keysMu.RUnlock()
+	if ok {
+		return sensitive
+	}
+
+	sensitive = m.keys.MatchString(key)
+	m.keysMu.Lock()
+	if len(m.keyCache) < maxCachedKeys {
+		m.keyCache[key] = sensitive
+	}
+	m.keysMu.Unlock()
+	return sensitive
+}
+
+// replace returns the replacement of a sensitive value
+func (m *masker) replace(value string) string {
+	if m.mode == MaskHash {
+		sum := sha256.Sum256([]byte(m.salt + value))
+		return "sha256:" + hex.EncodeToString(sum[:8])
+	}
+	return redacted
+}
+
+// detects reports whether any detector has a candidate in s
+func (m *masker) detects(s string) bool {
+	for _, detector := range m.detectors {
+		if detector.Pattern.MatchString(s) {
+			return true
+		}
+	}
+	return false
+}
+
+// maskString replaces every detected sensitive value in s, reporting whether any was found
+// Detectors without a candidate are skipped, as replacing copies the string even without matches
+func (m *masker) maskString(s string) (string, bool) {
+	changed := false
+	for _, detector := range m.detectors {
+		if !detector.Pattern.MatchString(s) {
+			continue
+		}
+		validate := detector.Validate
+		s = detector.Pattern.ReplaceAllStringFunc(s, func(match string) string {
+			if validate != nil && !validate(match) {
+				return match
+			}
+			changed = true
+			return m.replace(match)
+		})
+	}
+	return s, changed
+}
+
+// maskFields returns the fields with sensitive values masked, reporting whether any was
+// The slice is only copied if a field changes
+func (m *masker) maskFields(fields []zapcore.Field) ([]zapcore.Field, bool) {
+	var masked []zapcore.Field
+	for i, field := range fields {
+		replacement, changed := m.maskField(field)
+		if !changed {
+			continue
+		}
+		if masked == nil {
+			masked = make([]zapcore.Field, len(fields), len(fields)+1)
+			copy(masked, fields)
+		}
+		masked[i] = replacement
+	}
+	if masked == nil {
+		return fields, false
+	}
+	return masked, true
+}
+
+// maskField returns the field with sensitive values masked, reporting whether any was
+// Values are only searched in strings and in the strings nested in maps, slices and structs;
+// other typed fields such as numbers, errors and stringers are masked by key only
+func (m *masker) maskField(field zapcore.Field) (zapcore.Field, bool) {
+	if field.Type == zapcore.SkipType {
+		return field, false
+	}
+	if m.sensitiveKey(field.Key) {
+		return zap.String(field.Key, m.replace(fieldText(field))), true
+	}
+
+	var text string
+	switch field.Type {
+	case zapcore.StringType:
+		text = field.String
+	case zapcore.ByteStringType:
+		text = string(field.Interface.([]byte))
+	case zapcore.ReflectType, zapcore.ArrayMarshalerType, zapcore.ObjectMarshalerType:
+		// Structured values are walked first, so only those with a candidate pay for the round trip
+		value := field.Interface
+		if field.Type != zapcore.ReflectType {
+			value = encodedValue(field)
+		}
+		if !m.mayMask(value, 0) {
+			return field, false
+		}
+		decoded, ok := jsonValue(value)
+		if !ok {
+			return field, false
+		}
+		if masked, changed := m.maskValue(decoded); changed {
+			return zap.Any(field.Key, masked), true
+		}
+		return field, false
+	default:
+		return field, false
+	}
+
+	if masked, changed := m.maskString(text); changed {
+		return zap.String(field.Key, masked), true
+	}
+	return field, false
+}
+
+// maxMaskDepth bounds how deep mayMask walks nested values; deeper values are assumed to match
+const maxMaskDepth = 32
+
+// Types whose JSON form cannot be predicted from their Go value
+var (
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	timeType          = reflect.TypeOf(time.Time{})
+)
+
+// mayMask reports whether masking could change a value, i.e. whether it holds a sensitive
+// key or a string with a detector candidate, walking it as the JSON encoder would see it
+// Common field types are matched directly and others through reflection; values with
+// their own JSON or text encoding are assumed to match
+func (m *masker) mayMask(value interface{}, depth int) bool {
+	if depth > maxMaskDepth {
+		return true
+	}
+	switch v := value.(type) {
+	case nil, bool, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64,
+		float32, float64, time.Time, time.Duration:
+		return false
+	case string:
+		return m.detects(v)
+	case []string:
+		for _, nested := range v {
+			if m.detects(nested) {
+				return true
+			}
+		}
+		return false
+	case []interface{}:
+		for _, nested := range v {
+			if m.mayMask(nested, depth+1) {
+				return true
+			}
+		}
+		return false
+	case map[string]string:
+		for key, nested := range v {
+			if m.sensitiveKey(key) || m.detects(nested) {
+				return true
+			}
+		}
+		return false
+	case map[string]interface{}:
+		for key, nested := range v {
+			if m.sensitiveKey(key) || m.mayMask(nested, depth+1) {
+				return true
+			}
+		}
+		return false
+	}
+	return m.mayMaskValue(reflect.ValueOf(value), depth)
+}
+
+// mayMaskValue is mayMask for values of other types, walked through reflection
+func (m *masker) mayMaskValue(v reflect.Value, depth int) bool {
+	if depth > maxMaskDepth {
+		return true
+	}
+	if !v.IsValid() {
+		return false
+	}
+	t := v.Type()
+	if t == timeType {
+		return false
+	}
+	if t.Implements(jsonMarshalerType) || t.Implements(textMarshalerType) ||
+		reflect.PointerTo(t).Implements(jsonMarshalerType) || reflect.PointerTo(t).Implements(textMarshalerType) {
+		return true
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		return m.detects(v.String())
+	case reflect.Pointer, reflect.Interface:
+		return !v.IsNil() && m.mayMaskValue(v.Elem(), depth+1)
+	case reflect.Map:
+		iter := v.MapRange()
+		for iter.Next() {
+			key := iter.Key()
+			if key.Kind() != reflect.String || m.sensitiveKey(key.String()) || m.mayMaskValue(iter.Value(), depth+1) {
+				return true
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return false // Bytes are encoded as base64
+		}
+		for i := 0; i < v.Len(); i++ {
+			if m.mayMaskValue(v.Index(i), depth+1) {
+				return true
+			}
+		}
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() && !field.Anonymous {
+				continue
+			}
+			name := field.Name
+			if tag, ok := field.Tag.Lookup("json"); ok {
+				if tag == "-" {
+					continue
+				}
+				if tagName, _, _ := strings.Cut(tag, ","); tagName != "" {
+					name = tagName
+				}
+			}
+			if (!field.Anonymous && m.sensitiveKey(name)) || m.mayMaskValue(v.Field(i), depth+1) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// maskValue masks a decoded JSON value, walking nested maps and arrays
+// Numbers are converted back from json.Number and never masked by value: numeric IDs
+// pass the card number check too often. Sensitive keys still mask them
+func (m *masker) maskValue(value interface{}) (interface{}, bool) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		changed := false
+		for key, nested := range v {
+			if m.sensitiveKey(key) {
+				v[key], changed = m.replace(valueText(nested)), true
+				continue
+			}
+			masked, nestedChanged := m.maskValue(nested)
+			v[key], changed = masked, changed || nestedChanged
+		}
+		return v, changed
+	case []interface{}:
+		changed := false
+		for i, nested := range v {
+			masked, nestedChanged := m.maskValue(nested)
+			v[i], changed = masked, changed || nestedChanged
+		}
+		return v, changed
+	case string:
+		return m.maskString(v)
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i, false
+		}
+		f, _ := v.Float64()
+		return f, false
+	}
+	return value, false
+}
+
+// fieldValue returns a field's value as decoded JSON: maps, arrays, strings, numbers and booleans
+// Structs appear with their JSON field names, as the JSON encoder writes them
+func fieldValue(field zapcore.Field) (interface{}, bool) {
+	return jsonValue(encodedValue(field))
+}
+
+// encodedValue returns the value a field adds to an encoder, e.g. the map an
+// ObjectMarshaler builds
+func encodedValue(field zapcore.Field) interface{} {
+	enc := zapcore.NewMapObjectEncoder()
+	field.AddTo(enc)
+	return enc.Fields[field.Key]
+}
+
+// jsonValue converts a value to decoded JSON by encoding and decoding it
+func jsonValue(value interface{}) (interface{}, bool) {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return nil, false
+	}
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	var decoded interface{}
+	if err := decoder.Decode(&decoded); err != nil {
+		return nil, false
+	}
+	return decoded, true
+}
+
+// fieldText returns a textual form of a field's value, the input for MaskHash
+func fieldText(field zapcore.Field) string {
+	switch field.Type {
+	case zapcore.StringType:
+		return field.String
+	case zapcore.ByteStringType, zapcore.BinaryType:
+		return string(field.Interface.([]byte))
+	}
+	if value, ok := fieldValue(field); ok {
+		return valueText(value)
+	}
+	return ""
+}
+
+// valueText returns a textual form of a decoded JSON value, the input for MaskHash
+func valueText(value interface{}) string {
+	if s, ok := value.(string); ok {
+		return s
+	}
+	raw, _ := json.Marshal(value)
+	return string(raw)
+}
+
+// luhnValid reports whether the digits of s, ignoring spaces and dashes, form a number
+// of 13 to 19 digits with a valid Luhn checksum
+func luhnValid(s string) bool {
+	sum, digits := 0, 0
+	for i := len(s) - 1; i >= 0; i-- {
+		c := s[i]
+		if c == ' ' || c == '-' {
+			continue
+		}
+		if c < '0' || c > '9' {
+			return false
+		}
+		d := int(c - '0')
+		if digits%2 == 1 {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		digits++
+	}
+	return digits >= 13 && digits <= 19 && sum%10 == 0
+}
+
+// maskingCore masks field values before they reach the wrapped core
+// It wraps a sinkTee of all outputs, so each entry is masked once however many sinks write it
+type maskingCore struct {
+	zapcore.Core
+	masker *masker
+	marked bool // Fields added with With were masked, so every entry gets the marker
+}
+
+// With masks the fields before adding them to the wrapped core
+func (c *maskingCore) With(fields []zapcore.Field) zapcore.Core {
+	masked, changed := c.masker.maskFields(fields)
+	return &maskingCore{Core: c.Core.With(masked), masker: c.masker, marked: c.marked || changed}
+}
+
+// Check adds this core, not the wrapped one, so entries pass through Write
+func (c *maskingCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+// Write masks the entry's fields and adds the MaskedKey marker if any value was masked
+func (c *maskingCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	masked, changed := c.masker.maskFields(fields)
+	if changed || c.marked {
+		masked = append(masked[:len(masked):len(masked)], zap.Bool(MaskedKey, true))
+	}
+	return c.Core.Write(ent, masked)
+}
+
+// sinkTee duplicates entries to several cores like zapcore.NewTee, except that Write only
+// passes an entry to the cores enabled at its level. zap's tee leaves that to Check, which
+// a core processing entries for all sinks, such as maskingCore, replaces with its own
+type sinkTee []zapcore.Core
+
+// Enabled reports whether any core takes entries of the level
+func (t sinkTee) Enabled(level zapcore.Level) bool {
+	for _, core := range t {
+		if core.Enabled(level) {
+			return true
+		}
+	}
+	return false
+}
+
+// With adds the fields to every core
+func (t sinkTee) With(fields []zapcore.Field) zapcore.Core {
+	cores := make(sinkTee, len(t))
+	for i, core := range t {
+		cores[i] = core.With(fields)
+	}
+	return cores
+}
+
+// Check lets every core add itself to the entry
+func (t sinkTee) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	for _, core := range t {
+		ce = core.Check(ent, ce)
+	}
+	return ce
+}
+
+// Write writes the entry to the cores enabled at its level
+func (t sinkTee) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	var errs []error
+	for _, core := range t {
+		if !core.Enabled(ent.Level) {
+			continue
+		}
+		if err := core.Write(ent, fields); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return stderrors.Join(errs...)
+}
+
+// Sync flushes every core
+func (t sinkTee) Sync() error {
+	var errs []error
+	for _, core := range t {
+		if err := core.Sync(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return stderrors.Join(errs...)
+}
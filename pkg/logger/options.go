@@ -0,0 +1,284 @@
+package logger
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// =============================================================================
+// LOGGER OPTIONS
+// =============================================================================
+
+// Supported values of Options.Encoding
+const (
+	EncodingJSON    = "json"    // Structured, machine-readable entries for log aggregation
+	EncodingConsole = "console" // Human-readable entries using zap's development format
+	EncodingPretty  = "pretty"  // Colored, aligned entries for reading in a local terminal
+)
+
+// Paths of the standard streams in OutputConfig.Path and Options.ErrorOutputPaths
+const (
+	OutputStdout = "stdout"
+	OutputStderr = "stderr"
+)
+
+// OutputConfig describes one sink log entries are written to
+// Every entry enabled by the logger level goes to all sinks whose own minimum level it meets
+type OutputConfig struct {
+	Path     string          // OutputStdout, OutputStderr or a file path; missing directories are created
+	Level    string          // Minimum level for this sink, e.g. "error"; empty writes every entry
+	Rotation *RotationConfig // Rotation of a file sink; nil appends to the file forever
+}
+
+// Options describes how the root logger writes entries
+// SetLogLevel and SetFormatter each change one option and keep all others,
+// SetOptions replaces them as a whole
+type Options struct {
+	Level            zapcore.Level   // Minimum level of written entries
+	Encoding         string          // EncodingJSON, EncodingConsole or EncodingPretty
+	Outputs          []OutputConfig  // Sinks for log entries; empty writes to stdout
+	ErrorOutputPaths []string        // Sinks for internal logger errors, e.g. failed writes
+	Sampling         *SamplingConfig // Sampling and per-level rate limits; nil writes every entry
+	Masking          *MaskingConfig  // Masking of secrets and personal data in fields; nil writes values unchanged
+	Caller           bool            // Annotates entries with the calling file and line
+	StacktraceLevel  zapcore.Level   // Minimum level at which a stack trace is attached
+}
+
+// DefaultOptions returns the options used by Initialize: JSON entries at info level
+// to stdout, DefaultSamplingConfig, DefaultMaskingConfig, caller annotation and stack
+// traces for errors
+func DefaultOptions() Options {
+	sampling := DefaultSamplingConfig()
+	masking := DefaultMaskingConfig()
+	return Options{
+		Level:            zap.InfoLevel,
+		Encoding:         EncodingJSON,
+		Outputs:          []OutputConfig{{Path: OutputStdout}},
+		ErrorOutputPaths: []string{OutputStderr},
+		Sampling:         &sampling,
+		Masking:          &masking,
+		Caller:           true,
+		StacktraceLevel:  zap.ErrorLevel,
+	}
+}
+
+// normalized returns a copy of the options with empty values replaced by the defaults
+// The slices, sampling and masking configs are copied so callers cannot change a stored snapshot
+func (o Options) normalized() Options {
+	defaults := DefaultOptions()
+	o.Encoding = parseEncoding(o.Encoding)
+	if len(o.Outputs) == 0 {
+		o.Outputs = defaults.Outputs
+	}
+	if len(o.ErrorOutputPaths) == 0 {
+		o.ErrorOutputPaths = defaults.ErrorOutputPaths
+	}
+	outputs := make([]OutputConfig, len(o.Outputs))
+	for i, output := range o.Outputs {
+		if output.Rotation != nil {
+			rotation := *output.Rotation
+			output.Rotation = &rotation
+		}
+		outputs[i] = output
+	}
+	o.Outputs = outputs
+	o.ErrorOutputPaths = append([]string(nil), o.ErrorOutputPaths...)
+	if o.Sampling != nil {
+		sampling := o.Sampling.normalized()
+		o.Sampling = &sampling
+	}
+	if o.Masking != nil {
+		masking := o.Masking.normalized()
+		o.Masking = &masking
+	}
+	return o
+}
+
+// encoder returns the encoder for the configured encoding and a sink path
+// The pretty encoder collapses the given default fields into a header line and
+// writes colors only to stdout and stderr, never into files
+func (o Options) encoder(defaults Fields, path string) zapcore.Encoder {
+	switch o.Encoding {
+	case EncodingConsole:
+		return zapcore.NewConsoleEncoder(zap.NewDevelopmentEncoderConfig())
+	case EncodingPretty:
+		return newPrettyEncoder(defaults, path == OutputStdout || path == OutputStderr)
+	}
+	encoderConfig := zap.NewProductionEncoderConfig()
+	encoderConfig.TimeKey = "timestamp"                   // Use "timestamp" as the time field key
+	encoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder // ISO8601 time format
+	return zapcore.NewJSONEncoder(encoderConfig)
+}
+
+// build creates the logger state for the options with the default fields bound
+// The level is shared so SetLogLevel can change it without rebuilding the logger
+// Files already open in the given set are reused, so a file is never written by two rotating
+// writers; the state's set holds every file the new logger writes to
+func (o Options) build(level zap.AtomicLevel, defaults Fields, open fileSet) (*loggerState, error) {
+	files := fileSet{}
+	fail := func(err error) (*loggerState, error) {
+		files.closeExcept(open) // Close only the files opened for this build
+		return nil, err
+	}
+
+	var mask *masker
+	if o.Masking != nil {
+		var err error
+		if mask, err = newMasker(*o.Masking); err != nil {
+			return fail(err)
+		}
+	}
+
+	cores := make([]zapcore.Core, 0, len(o.Outputs))
+	for _, output := range o.Outputs {
+		sink, err := files.sink(output.Path, output.Rotation, open)
+		if err != nil {
+			return fail(err)
+		}
+		// One encoder per sink, so each sink repeats the pretty header when it needs to
+		cores = append(cores, zapcore.NewCore(o.encoder(defaults, output.Path), sink, sinkLevel(output.Level)))
+	}
+	cores = append(cores, &hookCore{}) // Registered hooks, see AddHook
+	core := zapcore.NewTee(cores...)
+	if mask != nil {
+		// Entries are masked once for all sinks; the sink tee still applies the sink levels
+		core = &maskingCore{Core: sinkTee(cores), masker: mask}
+	}
+	unsampled := core
+	if o.Sampling != nil {
+		core = o.Sampling.wrap(core)
+	}
+
+	errorSinks := make([]zapcore.WriteSyncer, 0, len(o.ErrorOutputPaths))
+	for _, path := range o.ErrorOutputPaths {
+		sink, err := files.sink(path, nil, open)
+		if err != nil {
+			return fail(err)
+		}
+		errorSinks = append(errorSinks, sink)
+	}
+
+	zapOptions := []zap.Option{
+		zap.ErrorOutput(zapcore.NewMultiWriteSyncer(errorSinks...)),
+		zap.AddStacktrace(o.StacktraceLevel),
+	}
+	if o.Caller {
+		zapOptions = append(zapOptions, zap.AddCaller())
+	}
+
+	// The logger level and per-logger overrides are applied before sampling and the sinks
+	// Add default fields to the new logger to preserve them
+	defaultZapFields := fieldsToZapFields(defaults)
+	return &loggerState{
+		zap:       zap.New(&levelCore{Core: core, level: level}, zapOptions...).With(defaultZapFields...),
+		unsampled: zap.New(&levelCore{Core: unsampled, level: level}, zapOptions...).With(defaultZapFields...).Named("logger"),
+		defaults:  defaults,
+		options:   o,
+		level:     level,
+		files:     files,
+	}, nil
+}
+
+// sinkLevel returns the minimum level of one sink; the logger level is applied by levelCore
+func sinkLevel(minimum string) zapcore.LevelEnabler {
+	if minimum == "" {
+		return zapcore.DebugLevel // Every entry the logger level allows
+	}
+	return parseLevel(minimum)
+}
+
+// Writers of the OutputStdout and OutputStderr sinks, replaced in benchmarks
+var (
+	stdoutSink zapcore.WriteSyncer = os.Stdout
+	stderrSink zapcore.WriteSyncer = os.Stderr
+)
+
+// fileSet holds the open log files of a logger by path
+type fileSet map[string]*rotatingFile
+
+// sink returns the write syncer for a path, reusing a file already open in open
+// and recording the file in the set
+func (s fileSet) sink(path string, rotation *RotationConfig, open fileSet) (zapcore.WriteSyncer, error) {
+	switch path {
+	case OutputStdout:
+		return zapcore.Lock(stdoutSink), nil
+	case OutputStderr:
+		return zapcore.Lock(stderrSink), nil
+	case "":
+		return nil, fmt.Errorf("log output path is empty")
+	}
+
+	var config RotationConfig
+	if rotation != nil {
+		config = *rotation
+	}
+	if file, ok := s[path]; ok {
+		if file.currentRotation() != config {
+			return nil, fmt.Errorf("log file %s is configured twice with different rotation", path)
+		}
+		return file, nil
+	}
+	if file, ok := open[path]; ok {
+		file.configure(config)
+		s[path] = file
+		return file, nil
+	}
+	file, err := openRotatingFile(path, config)
+	if err != nil {
+		return nil, err
+	}
+	s[path] = file
+	return file, nil
+}
+
+// closeExcept closes the files of the set that are not in keep
+func (s fileSet) closeExcept(keep fileSet) {
+	for path, file := range s {
+		if _, ok := keep[path]; !ok {
+			_ = file.Close()
+		}
+	}
+}
+
+// parseLevel converts a level name to zap's level type
+// Valid levels: debug, info, warn/warning, error, fatal; anything else is info
+func parseLevel(level string) zapcore.Level {
+	if lvl, ok := lookupLevel(level); ok {
+		return lvl
+	}
+	return zap.InfoLevel // Default to info for invalid levels
+}
+
+// lookupLevel converts a level name to zap's level type, reporting whether the name is valid
+func lookupLevel(level string) (zapcore.Level, bool) {
+	switch strings.ToLower(level) {
+	case "debug":
+		return zap.DebugLevel, true
+	case "info":
+		return zap.InfoLevel, true
+	case "warn", "warning":
+		return zap.WarnLevel, true
+	case "error":
+		return zap.ErrorLevel, true
+	case "fatal":
+		return zap.FatalLevel, true
+	}
+	return zap.InfoLevel, false
+}
+
+// parseEncoding converts a format name to an encoding
+// "text" and "console" select console output, "pretty" and "local" the pretty encoder;
+// anything else is JSON
+func parseEncoding(format string) string {
+	switch strings.ToLower(format) {
+	case "text", "console":
+		return EncodingConsole
+	case "pretty", "local":
+		return EncodingPretty
+	}
+	return EncodingJSON // Default to JSON for unknown formats
+}
@@ -0,0 +1,170 @@
+package logger
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	errorcodes "github.com/BhaveshKaushal/base-lib/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// readLog flushes the logger and returns the content of the log file
+func readLog(t *testing.T, path string) string {
+	t.Helper()
+	Sync()
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	return string(content)
+}
+
+// TestSetFormatterKeepsLevel tests that changing the format keeps a level set before
+func TestSetFormatterKeepsLevel(t *testing.T) {
+	path := initializeForTest(t, LoggerConfig{AppName: "test-app", Environment: "test"})
+
+	SetLogLevel("debug")
+	SetFormatter("console")
+	Debug("console debug message", nil)
+
+	output := readLog(t, path)
+	assert.Contains(t, output, "DEBUG")
+	assert.Contains(t, output, "console debug message")
+	assert.NotContains(t, output, `"level":"debug"`)
+
+	opts := GetOptions()
+	assert.Equal(t, zap.DebugLevel, opts.Level)
+	assert.Equal(t, EncodingConsole, opts.Encoding)
+	assert.Equal(t, []OutputConfig{{Path: path}}, opts.Outputs, "outputs are kept when the format changes")
+}
+
+// TestSetLogLevelKeepsLogger tests that changing the level neither rebuilds the logger nor changes the format
+func TestSetLogLevelKeepsLogger(t *testing.T) {
+	path := initializeForTest(t, LoggerConfig{AppName: "test-app", Environment: "test"})
+	SetFormatter("text")
+
+	before := GetLogger()
+	SetLogLevel("warn")
+	assert.Same(t, before, GetLogger(), "level changes must not rebuild the logger")
+
+	Info("filtered info message", nil)
+	Warn("console warn message", nil)
+
+	output := readLog(t, path)
+	assert.NotContains(t, output, "filtered info message")
+	assert.Contains(t, output, "WARN")
+	assert.Contains(t, output, "console warn message")
+	assert.Equal(t, EncodingConsole, GetOptions().Encoding)
+}
+
+// TestSetOptions tests replacing all options and the handling of build failures
+func TestSetOptions(t *testing.T) {
+	initializeForTest(t, LoggerConfig{AppName: "test-app", Environment: "test"})
+
+	path := filepath.Join(t.TempDir(), "other.log")
+	require.NoError(t, SetOptions(Options{Level: zap.WarnLevel, Outputs: []OutputConfig{{Path: path}}}))
+
+	opts := GetOptions()
+	assert.Equal(t, zap.WarnLevel, opts.Level)
+	assert.Equal(t, EncodingJSON, opts.Encoding, "empty options are filled with defaults")
+	assert.Equal(t, []string{"stderr"}, opts.ErrorOutputPaths)
+	assert.Nil(t, opts.Sampling)
+
+	Warn("json warn message", nil)
+	output := readLog(t, path)
+	assert.Contains(t, output, `"level":"warn"`)
+	assert.Contains(t, output, `"app_name":"test-app"`, "default fields are kept")
+
+	// Options returned by GetOptions are copies
+	opts.Outputs[0].Path = "changed"
+	assert.Equal(t, path, GetOptions().Outputs[0].Path)
+
+	// A logger that cannot be built keeps the current one
+	before := GetLogger()
+	err := SetOptions(Options{Outputs: []OutputConfig{{Path: t.TempDir()}}})
+	assert.Error(t, err)
+	assert.Same(t, before, GetLogger())
+	assert.Equal(t, zap.WarnLevel, GetOptions().Level)
+}
+
+// TestOutputsWithSinkLevels tests writing to several sinks with their own minimum levels
+func TestOutputsWithSinkLevels(t *testing.T) {
+	restoreLogger(t)
+	dir := t.TempDir()
+	allPath := filepath.Join(dir, "app.log")
+	errorPath := filepath.Join(dir, "errors", "app-errors.log")
+
+	Initialize(LoggerConfig{
+		AppName: "test-app",
+		Outputs: []OutputConfig{
+			{Path: allPath},
+			{Path: errorPath, Level: "error", Rotation: &RotationConfig{MaxSizeMB: 10, MaxBackups: 3}},
+		},
+	})
+	files := loadState().files
+	require.Len(t, files, 2)
+
+	// Rebuilding for a new format keeps writing through the same open files
+	SetFormatter("json")
+	for path, file := range loadState().files {
+		assert.Same(t, files[path], file, path)
+	}
+
+	Info("routine message", nil)
+	Error("failure message", errorcodes.NewErrDefault(errorcodes.ErrCodeDBQuery, "query failed", "test-app"), nil)
+
+	all := readLog(t, allPath)
+	assert.Contains(t, all, "routine message")
+	assert.Contains(t, all, "failure message")
+
+	errorsOnly := readLog(t, errorPath)
+	assert.NotContains(t, errorsOnly, "routine message")
+	assert.Contains(t, errorsOnly, "failure message")
+
+	// The logger level still applies to sinks with a lower minimum
+	SetLogLevel("error")
+	Warn("suppressed warning", nil)
+	assert.NotContains(t, readLog(t, allPath), "suppressed warning")
+
+	// Files the new logger does not use are closed
+	require.NoError(t, SetOptions(Options{Outputs: []OutputConfig{{Path: allPath}}}))
+	_, err := files[errorPath].Write([]byte("late entry\n"))
+	assert.Error(t, err)
+	assert.Same(t, files[allPath], loadState().files[allPath])
+}
+
+// TestParseLevelAndEncoding tests the conversion of level and format names
+func TestParseLevelAndEncoding(t *testing.T) {
+	levels := []struct {
+		name     string
+		expected zapcore.Level
+	}{
+		{name: "debug", expected: zap.DebugLevel},
+		{name: "INFO", expected: zap.InfoLevel},
+		{name: "warning", expected: zap.WarnLevel},
+		{name: "error", expected: zap.ErrorLevel},
+		{name: "fatal", expected: zap.FatalLevel},
+		{name: "invalid", expected: zap.InfoLevel},
+	}
+	for _, tt := range levels {
+		assert.Equal(t, tt.expected, parseLevel(tt.name), tt.name)
+	}
+
+	encodings := []struct {
+		name     string
+		expected string
+	}{
+		{name: "json", expected: EncodingJSON},
+		{name: "Text", expected: EncodingConsole},
+		{name: "CONSOLE", expected: EncodingConsole},
+		{name: "pretty", expected: EncodingPretty},
+		{name: "Local", expected: EncodingPretty},
+		{name: "", expected: EncodingJSON},
+		{name: "unknown", expected: EncodingJSON},
+	}
+	for _, tt := range encodings {
+		assert.Equal(t, tt.expected, parseEncoding(tt.name), tt.name)
+	}
+}
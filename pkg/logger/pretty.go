@@ -0,0 +1,227 @@
+package logger
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap/buffer"
+	"go.uber.org/zap/zapcore"
+)
+
+// =============================================================================
+// PRETTY CONSOLE ENCODER
+// =============================================================================
+
+// ANSI escape sequences used by the pretty encoder
+const (
+	ansiReset   = "\x1b[0m"
+	ansiDim     = "\x1b[2m"
+	ansiBold    = "\x1b[1m"
+	ansiRed     = "\x1b[31m"
+	ansiGreen   = "\x1b[32m"
+	ansiYellow  = "\x1b[33m"
+	ansiBlue    = "\x1b[34m"
+	ansiMagenta = "\x1b[35m"
+	ansiCyan    = "\x1b[36m"
+)
+
+// prettyMessageWidth is the column the fields of an entry start at, so they line up
+const prettyMessageWidth = 40
+
+// prettyBuffers recycles the buffers of encoded entries
+var prettyBuffers = buffer.NewPool()
+
+// prettyLevelColors maps levels to the color of their label
+var prettyLevelColors = map[zapcore.Level]string{
+	zapcore.DebugLevel:  ansiMagenta,
+	zapcore.InfoLevel:   ansiBlue,
+	zapcore.WarnLevel:   ansiYellow,
+	zapcore.ErrorLevel:  ansiRed,
+	zapcore.DPanicLevel: ansiRed,
+	zapcore.PanicLevel:  ansiRed,
+	zapcore.FatalLevel:  ansiRed,
+}
+
+// prettyHeader remembers the default fields last printed, shared by an encoder and its clones
+type prettyHeader struct {
+	mu   sync.Mutex
+	last string
+}
+
+// prettyEncoder writes one colored, aligned line per entry for reading in a terminal:
+//
+//	15:04:05.000 INFO  payments  Payment accepted              amount=42 currency=EUR
+//
+// Entries logged with an error (Error, LogErr) show their code and its description after
+// the message, stack traces follow on indented lines, and the default fields are printed
+// as a header line only when they change instead of on every entry
+// Colors are only written to terminals, i.e. stdout and stderr sinks
+type prettyEncoder struct {
+	*zapcore.MapObjectEncoder                 // Fields added with With
+	defaults                  map[string]bool // Keys of the default fields collapsed into the header
+	color                     bool            // Write ANSI colors
+	header                    *prettyHeader
+}
+
+// newPrettyEncoder returns a pretty encoder collapsing the given default fields
+func newPrettyEncoder(defaults Fields, color bool) *prettyEncoder {
+	keys := make(map[string]bool, len(defaults))
+	for k := range defaults {
+		keys[k] = true
+	}
+	return &prettyEncoder{
+		MapObjectEncoder: zapcore.NewMapObjectEncoder(),
+		defaults:         keys,
+		color:            color,
+		header:           &prettyHeader{},
+	}
+}
+
+// Clone returns a copy of the encoder sharing the header state
+func (e *prettyEncoder) Clone() zapcore.Encoder {
+	clone := zapcore.NewMapObjectEncoder()
+	for k, v := range e.Fields {
+		clone.Fields[k] = v
+	}
+	return &prettyEncoder{MapObjectEncoder: clone, defaults: e.defaults, color: e.color, header: e.header}
+}
+
+// paint returns s in the given colors, unchanged if colors are disabled
+func (e *prettyEncoder) paint(s string, colors ...string) string {
+	if !e.color {
+		return s
+	}
+	return strings.Join(colors, "") + s + ansiReset
+}
+
+// EncodeEntry writes an entry with its fields as one line, plus the header and stack trace if any
+func (e *prettyEncoder) EncodeEntry(ent zapcore.Entry, fields []zapcore.Field) (*buffer.Buffer, error) {
+	all := e.Clone().(*prettyEncoder)
+	for _, field := range fields {
+		field.AddTo(all)
+	}
+
+	buf := prettyBuffers.Get()
+	e.writeHeader(buf, all.Fields)
+
+	// Time, level and logger name
+	buf.AppendString(e.paint(ent.Time.Format("15:04:05.000"), ansiDim) + " ")
+	buf.AppendString(e.paint(fmt.Sprintf("%-5s", ent.Level.CapitalString()), prettyLevelColors[ent.Level], ansiBold) + " ")
+	if ent.LoggerName != "" {
+		buf.AppendString(e.paint(ent.LoggerName, ansiCyan) + " ")
+	}
+
+	// Message, followed by the code and description of entries logged with an error
+	// Only entries written by the error functions carry both, other "code" fields stay listed
+	message := ent.Message
+	code, hasCode := all.Fields["code"]
+	description, hasDescription := all.Fields["code_description"]
+	inline := hasCode && hasDescription
+	if inline {
+		message += fmt.Sprintf(" [%v: %v]", code, description)
+	}
+	buf.AppendString(message)
+
+	// Remaining fields sorted by key, starting at a common column
+	keys := make([]string, 0, len(all.Fields))
+	for k := range all.Fields {
+		if e.defaults[k] || (inline && (k == "code" || k == "code_description")) {
+			continue
+		}
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	if len(keys) > 0 {
+		if pad := prettyMessageWidth - len(message); pad > 0 {
+			buf.AppendString(strings.Repeat(" ", pad))
+		}
+		for _, k := range keys {
+			buf.AppendString(" " + e.paint(k, ansiGreen) + "=" + prettyValue(all.Fields[k]))
+		}
+	}
+	if ent.Caller.Defined {
+		buf.AppendString(" " + e.paint(ent.Caller.TrimmedPath(), ansiDim))
+	}
+	buf.AppendByte('\n')
+
+	if ent.Stack != "" {
+		e.writeStack(buf, ent.Stack)
+	}
+	return buf, nil
+}
+
+// writeHeader writes the default fields of an entry if they differ from the last ones written
+func (e *prettyEncoder) writeHeader(buf *buffer.Buffer, fields map[string]interface{}) {
+	keys := make([]string, 0, len(e.defaults))
+	for k := range fields {
+		if e.defaults[k] {
+			keys = append(keys, k)
+		}
+	}
+	if len(keys) == 0 {
+		return
+	}
+	sort.Strings(keys)
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		parts = append(parts, k+"="+prettyValue(fields[k]))
+	}
+	header := strings.Join(parts, " ")
+
+	e.header.mu.Lock()
+	defer e.header.mu.Unlock()
+	if header == e.header.last {
+		return
+	}
+	e.header.last = header
+	buf.AppendString(e.paint("── "+header+" ──", ansiDim) + "\n")
+}
+
+// writeStack writes a stack trace with one indented line per frame
+// zap writes each frame as the function followed by its tab-indented location
+func (e *prettyEncoder) writeStack(buf *buffer.Buffer, stack string) {
+	lines := strings.Split(strings.TrimRight(stack, "\n"), "\n")
+	for i := 0; i < len(lines); i++ {
+		function := strings.TrimSpace(lines[i])
+		location := ""
+		if i+1 < len(lines) && strings.HasPrefix(lines[i+1], "\t") {
+			location = strings.TrimSpace(lines[i+1])
+			i++
+		}
+		buf.AppendString("    " + e.paint("at", ansiDim) + " " + function)
+		if location != "" {
+			buf.AppendString(" " + e.paint("("+location+")", ansiDim))
+		}
+		buf.AppendByte('\n')
+	}
+}
+
+// prettyValue formats a field value; strings with spaces are quoted, objects are JSON
+func prettyValue(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		if v == "" || strings.ContainsAny(v, " \t\n\"=") {
+			return fmt.Sprintf("%q", v)
+		}
+		return v
+	case time.Duration:
+		return v.String()
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	case []byte:
+		return fmt.Sprintf("%q", v)
+	case fmt.Stringer:
+		return v.String()
+	case error:
+		return fmt.Sprintf("%q", v.Error())
+	case map[string]interface{}, []interface{}:
+		if raw, err := json.Marshal(v); err == nil {
+			return string(raw)
+		}
+	}
+	return fmt.Sprint(value)
+}
@@ -0,0 +1,169 @@
+package logger
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	errorcodes "github.com/BhaveshKaushal/base-lib/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// ansiPattern matches the color escape sequences of the pretty encoder
+var ansiPattern = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+// stripColors removes color escape sequences from pretty output
+func stripColors(s string) string {
+	return ansiPattern.ReplaceAllString(s, "")
+}
+
+// encodePretty encodes one entry with the encoder and returns it without colors
+func encodePretty(t *testing.T, encoder zapcore.Encoder, entry zapcore.Entry, fields ...zapcore.Field) string {
+	t.Helper()
+	buf, err := encoder.EncodeEntry(entry, fields)
+	require.NoError(t, err)
+	defer buf.Free()
+	return stripColors(buf.String())
+}
+
+// TestPrettyEncoder tests the line written for single entries
+func TestPrettyEncoder(t *testing.T) {
+	at := time.Date(2024, 1, 2, 15, 4, 5, 123000000, time.UTC)
+
+	tests := []struct {
+		name     string
+		entry    zapcore.Entry
+		fields   []zapcore.Field
+		expected string
+	}{
+		{
+			name:     "message only",
+			entry:    zapcore.Entry{Time: at, Level: zap.InfoLevel, Message: "server started"},
+			expected: "15:04:05.123 INFO  server started\n",
+		},
+		{
+			name:     "fields aligned and sorted",
+			entry:    zapcore.Entry{Time: at, Level: zap.WarnLevel, LoggerName: "payments", Message: "slow payment"},
+			fields:   []zapcore.Field{zap.Int("amount", 42), zap.String("currency", "EUR"), zap.String("note", "two words")},
+			expected: "15:04:05.123 WARN  payments slow payment" + strings.Repeat(" ", 28) + ` amount=42 currency=EUR note="two words"` + "\n",
+		},
+		{
+			name:  "error code and description inline",
+			entry: zapcore.Entry{Time: at, Level: zap.ErrorLevel, Message: "loading order"},
+			fields: []zapcore.Field{zap.String("code", "DB_QUERY"), zap.String("code_description", "Database query failed"),
+				zap.String("message", "timeout")},
+			expected: "15:04:05.123 ERROR loading order [DB_QUERY: Database query failed] message=timeout\n",
+		},
+		{
+			name:     "code without description stays a field",
+			entry:    zapcore.Entry{Time: at, Level: zap.InfoLevel, Message: "resp"},
+			fields:   []zapcore.Field{zap.Int("code", 200)},
+			expected: "15:04:05.123 INFO  resp" + strings.Repeat(" ", 36) + " code=200\n",
+		},
+		{
+			name:     "objects as JSON",
+			entry:    zapcore.Entry{Time: at, Level: zap.DebugLevel, Message: "request"},
+			fields:   []zapcore.Field{zap.Any("headers", map[string]interface{}{"accept": "json"}), zap.Duration("took", 1500*time.Millisecond)},
+			expected: "15:04:05.123 DEBUG request" + strings.Repeat(" ", 33) + ` headers={"accept":"json"} took=1.5s` + "\n",
+		},
+		{
+			name: "caller",
+			entry: zapcore.Entry{Time: at, Level: zap.InfoLevel, Message: "ready",
+				Caller: zapcore.NewEntryCaller(0, "/src/app/server/main.go", 12, true)},
+			expected: "15:04:05.123 INFO  ready server/main.go:12\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, encodePretty(t, newPrettyEncoder(nil, true), tt.entry, tt.fields...))
+		})
+	}
+}
+
+// TestPrettyEncoderColors tests that levels are colored only if colors are enabled
+func TestPrettyEncoderColors(t *testing.T) {
+	entry := zapcore.Entry{Level: zap.ErrorLevel, Message: "failed", Stack: "main.main\n\t/src/app/main.go:8"}
+	fields := []zapcore.Field{zap.String("user", "alice")}
+
+	colored, err := newPrettyEncoder(nil, true).EncodeEntry(entry, fields)
+	require.NoError(t, err)
+	defer colored.Free()
+	assert.Contains(t, colored.String(), ansiRed+ansiBold+"ERROR"+ansiReset)
+
+	plain, err := newPrettyEncoder(nil, false).EncodeEntry(entry, fields)
+	require.NoError(t, err)
+	defer plain.Free()
+	assert.NotContains(t, plain.String(), "\x1b[")
+	assert.Equal(t, stripColors(colored.String()), plain.String())
+}
+
+// TestPrettyEncoderStack tests pretty-printing a stack trace below the entry
+func TestPrettyEncoderStack(t *testing.T) {
+	stack := "main.handler\n\t/src/app/main.go:20\nmain.main\n\t/src/app/main.go:8"
+	out := encodePretty(t, newPrettyEncoder(nil, true), zapcore.Entry{Level: zap.ErrorLevel, Message: "failed", Stack: stack})
+	assert.Equal(t, []string{
+		"00:00:00.000 ERROR failed",
+		"    at main.handler (/src/app/main.go:20)",
+		"    at main.main (/src/app/main.go:8)",
+	}, strings.Split(strings.TrimSuffix(out, "\n"), "\n")[0:3])
+}
+
+// TestPrettyEncoderHeader tests collapsing default fields into a header printed when they change
+func TestPrettyEncoderHeader(t *testing.T) {
+	encoder := newPrettyEncoder(Fields{"app_name": "shop", "environment": "local"}, true)
+	withDefaults := encoder.Clone()
+	withDefaults.AddString("app_name", "shop")
+	withDefaults.AddString("environment", "local")
+	entry := zapcore.Entry{Level: zap.InfoLevel, Message: "first"}
+
+	first := encodePretty(t, withDefaults, entry)
+	assert.Equal(t, "── app_name=shop environment=local ──\n00:00:00.000 INFO  first\n", first)
+
+	// Clones share the header, so the same defaults are not repeated
+	child := withDefaults.Clone()
+	child.AddString("request_id", "r-1")
+	entry.Message = "second"
+	second := encodePretty(t, child, entry)
+	assert.NotContains(t, second, "app_name")
+	assert.Contains(t, second, "request_id=r-1")
+
+	// Changed defaults print a new header
+	changed := withDefaults.Clone()
+	changed.AddString("environment", "staging")
+	assert.Contains(t, encodePretty(t, changed, entry), "── app_name=shop environment=staging ──\n")
+}
+
+// TestPrettyLocalEnvironment tests that the local environment selects the pretty encoder
+func TestPrettyLocalEnvironment(t *testing.T) {
+	restoreLogger(t)
+
+	Initialize(LoggerConfig{AppName: "test-app", Environment: "local"})
+	assert.Equal(t, EncodingPretty, GetOptions().Encoding)
+	Initialize(LoggerConfig{AppName: "test-app", Environment: "production"})
+	assert.Equal(t, EncodingJSON, GetOptions().Encoding)
+
+	// Explicit options are kept in the local environment
+	opts := DefaultOptions()
+	path := initializeForTest(t, LoggerConfig{AppName: "test-app", Environment: "local", Options: &opts})
+	assert.Equal(t, EncodingJSON, GetOptions().Encoding)
+
+	SetFormatter("pretty")
+	Info("first", Fields{"order_id": 7})
+	Error("loading order", errorcodes.NewErrDefault(errorcodes.ErrCodeDBQuery, "query failed", "test-app"), nil)
+	content := readLog(t, path)
+	assert.NotContains(t, content, "\x1b[", "files are written without colors")
+	lines := strings.Split(strings.TrimSpace(content), "\n")
+
+	require.GreaterOrEqual(t, len(lines), 4)
+	assert.Equal(t, 1, strings.Count(strings.Join(lines, "\n"), "app_name=test-app"), "defaults are printed once")
+	assert.True(t, strings.HasPrefix(lines[0], "── "), lines[0])
+	assert.Contains(t, lines[1], "first")
+	assert.Contains(t, lines[1], "order_id=7")
+	assert.Contains(t, lines[2], "loading order ["+string(errorcodes.ErrCodeDBQuery)+": ")
+	assert.Contains(t, lines[3], "    at ", "errors carry a pretty-printed stack trace")
+}
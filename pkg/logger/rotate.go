@@ -0,0 +1,300 @@
+package logger
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// =============================================================================
+// FILE ROTATION
+// =============================================================================
+
+// backupTimeFormat names rotated files; it sorts chronologically and contains no colons
+const backupTimeFormat = "2006-01-02T15-04-05.000"
+
+// RotationConfig controls when a log file is rotated and how many rotated files are kept
+// A rotated file is renamed to "<name>-<timestamp><ext>", e.g. "app-2024-01-02T15-04-05.000.log"
+type RotationConfig struct {
+	MaxSizeMB  int           // Rotate before the file grows beyond this size; 0 disables size-based rotation
+	MaxAge     time.Duration // Rotate once the file has been written for this long; 0 disables age-based rotation
+	MaxBackups int           // Number of rotated files kept, oldest are deleted first; 0 keeps all
+	Compress   bool          // Compress rotated files with gzip, adding a ".gz" suffix
+}
+
+// rotatingFile is a log file that rotates itself according to a RotationConfig
+// It is safe for concurrent use; compression and cleanup of rotated files run in the background
+type rotatingFile struct {
+	mu       sync.Mutex
+	path     string
+	rotation RotationConfig
+	maxSize  int64                               // Size limit in bytes derived from rotation.MaxSizeMB
+	file     *os.File                            // Current file, nil after Close or a failed reopen
+	closed   bool                                // Set by Close; writes fail from then on
+	size     int64                               // Bytes in the current file
+	openedAt time.Time                           // When the current file was started, for age-based rotation
+	now      func() time.Time                    // Clock, replaced in tests
+	rename   func(oldpath, newpath string) error // os.Rename, replaced in tests
+
+	millMu  sync.Mutex     // Serializes compression and cleanup of rotated files
+	milling sync.WaitGroup // Background compression and cleanup in progress
+}
+
+// openRotatingFile opens or creates the log file at path, creating missing directories
+// Entries are appended to an existing file
+func openRotatingFile(path string, rotation RotationConfig) (*rotatingFile, error) {
+	r := &rotatingFile{path: path, now: time.Now, rename: os.Rename}
+	r.configure(rotation)
+	if err := r.open(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// configure replaces the rotation settings, e.g. when the logger is rebuilt with new options
+func (r *rotatingFile) configure(rotation RotationConfig) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.rotation = rotation
+	r.maxSize = int64(rotation.MaxSizeMB) * 1024 * 1024
+}
+
+// currentRotation returns the rotation settings in effect
+func (r *rotatingFile) currentRotation() RotationConfig {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rotation
+}
+
+// open opens the file at r.path for appending; must be called with r.mu held or before sharing r
+func (r *rotatingFile) open() error {
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return fmt.Errorf("creating log directory: %w", err)
+	}
+	file, err := os.OpenFile(r.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("opening log file: %w", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("opening log file: %w", err)
+	}
+	r.file, r.size, r.openedAt = file, info.Size(), r.now()
+	return nil
+}
+
+// Write appends p to the file, rotating it first if p would exceed the size limit
+// or the file has reached its maximum age
+// A failed rotation is returned as the write error, but p is still written if the
+// current file could be reopened, so one failure never silences the sink
+func (r *rotatingFile) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.closed {
+		return 0, fmt.Errorf("log file %s is closed", r.path)
+	}
+	if r.file == nil {
+		// An earlier rotation could not reopen the file, try again
+		if err := r.open(); err != nil {
+			return 0, err
+		}
+	}
+	if r.shouldRotate(int64(len(p))) {
+		if err := r.rotate(); err != nil {
+			if r.file == nil {
+				return 0, err
+			}
+			n, writeErr := r.file.Write(p)
+			r.size += int64(n)
+			if writeErr != nil {
+				return n, writeErr
+			}
+			return n, err
+		}
+	}
+
+	n, err := r.file.Write(p)
+	r.size += int64(n)
+	return n, err
+}
+
+// shouldRotate reports whether the current file must be rotated before writing n bytes
+// A file is never rotated while empty, so single entries larger than the limit are still written
+func (r *rotatingFile) shouldRotate(n int64) bool {
+	if r.size == 0 {
+		return false
+	}
+	if r.maxSize > 0 && r.size+n > r.maxSize {
+		return true
+	}
+	return r.rotation.MaxAge > 0 && r.now().Sub(r.openedAt) >= r.rotation.MaxAge
+}
+
+// rotate renames the current file to a timestamped backup and starts a new one
+// Compression and removal of old backups run in the background
+// If the file cannot be closed or renamed it is reopened, so writing continues and the
+// rotation is retried with the next write; a failed reopen is retried by Write
+func (r *rotatingFile) rotate() error {
+	err := r.file.Close()
+	r.file = nil
+	if err != nil {
+		return r.reopenAfter(fmt.Errorf("closing log file: %w", err))
+	}
+
+	backup := r.backupName(r.now())
+	if err := r.rename(r.path, backup); err != nil {
+		return r.reopenAfter(fmt.Errorf("rotating log file: %w", err))
+	}
+
+	rotation := r.rotation
+	r.milling.Add(1)
+	go func() {
+		defer r.milling.Done()
+		r.mill(backup, rotation)
+	}()
+	return r.open()
+}
+
+// reopenAfter reopens the current file after a failed rotation and returns the rotation error
+func (r *rotatingFile) reopenAfter(err error) error {
+	if openErr := r.open(); openErr != nil {
+		return fmt.Errorf("%w (%v)", err, openErr)
+	}
+	return err
+}
+
+// backupName returns an unused name for the rotated file at the given time
+// If a backup, compressed or not, already has the name of that millisecond, the
+// timestamp moves on by a millisecond, so a rotation never overwrites a backup
+func (r *rotatingFile) backupName(t time.Time) string {
+	ext := filepath.Ext(r.path)
+	for {
+		name := fmt.Sprintf("%s-%s%s", strings.TrimSuffix(r.path, ext), t.Format(backupTimeFormat), ext)
+		if !fileExists(name) && !fileExists(name+".gz") {
+			return name
+		}
+		t = t.Add(time.Millisecond)
+	}
+}
+
+// fileExists reports whether a file or directory exists at path
+func fileExists(path string) bool {
+	_, err := os.Lstat(path)
+	return err == nil
+}
+
+// mill compresses a freshly rotated file and deletes backups beyond the retention count
+// Failures are reported on stderr because the logger cannot log about its own output
+func (r *rotatingFile) mill(backup string, rotation RotationConfig) {
+	r.millMu.Lock()
+	defer r.millMu.Unlock()
+
+	if rotation.Compress {
+		if err := compressFile(backup); err != nil {
+			fmt.Fprintf(os.Stderr, "logger: compressing %s: %v\n", backup, err)
+		}
+	}
+	if rotation.MaxBackups > 0 {
+		backups, err := r.backups()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "logger: listing backups of %s: %v\n", r.path, err)
+			return
+		}
+		for len(backups) > rotation.MaxBackups {
+			if err := os.Remove(backups[0]); err != nil {
+				fmt.Fprintf(os.Stderr, "logger: removing %s: %v\n", backups[0], err)
+			}
+			backups = backups[1:]
+		}
+	}
+}
+
+// backups returns the rotated files of r.path, oldest first
+func (r *rotatingFile) backups() ([]string, error) {
+	ext := filepath.Ext(r.path)
+	prefix := filepath.Base(strings.TrimSuffix(r.path, ext)) + "-"
+	entries, err := os.ReadDir(filepath.Dir(r.path))
+	if err != nil {
+		return nil, err
+	}
+
+	var backups []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		stamp := strings.TrimSuffix(strings.TrimSuffix(name, ".gz"), ext)
+		if _, err := time.Parse(backupTimeFormat, strings.TrimPrefix(stamp, prefix)); err != nil {
+			continue // Not a backup of this file
+		}
+		backups = append(backups, filepath.Join(filepath.Dir(r.path), name))
+	}
+	sort.Strings(backups)
+	return backups, nil
+}
+
+// Sync commits the current file to stable storage
+func (r *rotatingFile) Sync() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.file == nil {
+		return nil
+	}
+	return r.file.Sync()
+}
+
+// Close closes the current file and waits for background compression and cleanup
+func (r *rotatingFile) Close() error {
+	r.mu.Lock()
+	var err error
+	r.closed = true
+	if r.file != nil {
+		err = r.file.Close()
+		r.file = nil
+	}
+	r.mu.Unlock()
+
+	r.milling.Wait()
+	return err
+}
+
+// compressFile replaces path with a gzip-compressed copy named path + ".gz"
+func compressFile(path string) error {
+	src, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	dst, err := os.OpenFile(path+".gz", os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	gz := gzip.NewWriter(dst)
+	if _, err := io.Copy(gz, src); err != nil {
+		dst.Close()
+		os.Remove(path + ".gz")
+		return err
+	}
+	if err := gz.Close(); err != nil {
+		dst.Close()
+		os.Remove(path + ".gz")
+		return err
+	}
+	if err := dst.Close(); err != nil {
+		os.Remove(path + ".gz")
+		return err
+	}
+
+	src.Close()
+	return os.Remove(path)
+}
@@ -0,0 +1,173 @@
+package logger
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClock returns a clock for rotatingFile.now that advances only when told to
+func fakeClock(start time.Time) (func() time.Time, func(time.Duration)) {
+	now := start
+	return func() time.Time { return now }, func(d time.Duration) { now = now.Add(d) }
+}
+
+// openTestFile opens a rotating file with a fake clock and a size limit in bytes
+func openTestFile(t *testing.T, rotation RotationConfig, maxSize int64) (*rotatingFile, func(time.Duration)) {
+	t.Helper()
+	r, err := openRotatingFile(filepath.Join(t.TempDir(), "app.log"), rotation)
+	require.NoError(t, err)
+	t.Cleanup(func() { r.Close() })
+
+	now, advance := fakeClock(time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC))
+	r.now, r.openedAt = now, now()
+	if maxSize > 0 {
+		r.maxSize = maxSize
+	}
+	return r, advance
+}
+
+// writeEntries writes the given entries, failing the test on errors
+func writeEntries(t *testing.T, r *rotatingFile, entries ...string) {
+	t.Helper()
+	for _, entry := range entries {
+		_, err := r.Write([]byte(entry))
+		require.NoError(t, err)
+	}
+}
+
+// readFile returns the content of a file, decompressing gzip files
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	var reader io.Reader = f
+	if strings.HasSuffix(path, ".gz") {
+		gz, err := gzip.NewReader(f)
+		require.NoError(t, err)
+		defer gz.Close()
+		reader = gz
+	}
+	content, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	return string(content)
+}
+
+// TestRotatingFileSize tests size-based rotation and the retention count
+func TestRotatingFileSize(t *testing.T) {
+	r, advance := openTestFile(t, RotationConfig{MaxBackups: 2}, 10)
+
+	writeEntries(t, r, "entry-1\n") // 8 bytes, fits
+	advance(time.Second)
+	writeEntries(t, r, "entry-2\n") // would exceed 10 bytes, rotates first
+	advance(time.Second)
+	writeEntries(t, r, "entry-3\n")
+	advance(time.Second)
+	writeEntries(t, r, "entry-4\n")
+	r.milling.Wait()
+
+	backups, err := r.backups()
+	require.NoError(t, err)
+	require.Len(t, backups, 2, "the oldest backup is removed")
+	assert.Equal(t, filepath.Join(filepath.Dir(r.path), "app-2024-01-02T15-04-07.000.log"), backups[0])
+	assert.Equal(t, "entry-2\n", readFile(t, backups[0]))
+	assert.Equal(t, "entry-3\n", readFile(t, backups[1]))
+	assert.Equal(t, "entry-4\n", readFile(t, r.path))
+}
+
+// TestRotatingFileAge tests age-based rotation with compression of rotated files
+func TestRotatingFileAge(t *testing.T) {
+	r, advance := openTestFile(t, RotationConfig{MaxAge: time.Hour, Compress: true}, 0)
+
+	writeEntries(t, r, "first hour\n")
+	advance(30 * time.Minute)
+	writeEntries(t, r, "still first hour\n")
+	advance(30 * time.Minute)
+	writeEntries(t, r, "second hour\n")
+	r.milling.Wait()
+
+	backups, err := r.backups()
+	require.NoError(t, err)
+	require.Len(t, backups, 1)
+	assert.True(t, strings.HasSuffix(backups[0], ".log.gz"), backups[0])
+	assert.Equal(t, "first hour\nstill first hour\n", readFile(t, backups[0]))
+	assert.Equal(t, "second hour\n", readFile(t, r.path))
+
+	_, err = os.Stat(strings.TrimSuffix(backups[0], ".gz"))
+	assert.True(t, os.IsNotExist(err), "the uncompressed backup is removed")
+}
+
+// TestRotatingFileLimits tests entries larger than the limit, appending and writes after Close
+func TestRotatingFileLimits(t *testing.T) {
+	r, _ := openTestFile(t, RotationConfig{}, 4)
+
+	writeEntries(t, r, "larger than the limit\n")
+	backups, err := r.backups()
+	require.NoError(t, err)
+	assert.Empty(t, backups, "an empty file is never rotated")
+
+	// Reopening appends to the existing file
+	require.NoError(t, r.Close())
+	reopened, err := openRotatingFile(r.path, RotationConfig{})
+	require.NoError(t, err)
+	writeEntries(t, reopened, "appended\n")
+	require.NoError(t, reopened.Close())
+	assert.Equal(t, "larger than the limit\nappended\n", readFile(t, r.path))
+
+	_, err = reopened.Write([]byte("after close\n"))
+	assert.Error(t, err)
+	assert.NoError(t, reopened.Sync())
+}
+
+// TestRotatingFileRenameFailure tests that a failed rotation keeps the sink writing
+func TestRotatingFileRenameFailure(t *testing.T) {
+	r, advance := openTestFile(t, RotationConfig{}, 10)
+	renameErr := fmt.Errorf("device busy")
+	r.rename = func(string, string) error { return renameErr }
+
+	writeEntries(t, r, "entry-1\n")
+	advance(time.Second)
+	n, err := r.Write([]byte("entry-2\n"))
+	assert.ErrorIs(t, err, renameErr, "the failed rotation is reported")
+	assert.Equal(t, 8, n, "the entry is still written")
+
+	// Once renaming works again the next write rotates
+	r.rename = os.Rename
+	advance(time.Second)
+	writeEntries(t, r, "entry-3\n")
+	r.milling.Wait()
+
+	backups, err := r.backups()
+	require.NoError(t, err)
+	require.Len(t, backups, 1)
+	assert.Equal(t, "entry-1\nentry-2\n", readFile(t, backups[0]))
+	assert.Equal(t, "entry-3\n", readFile(t, r.path))
+}
+
+// TestRotatingFileBackupCollision tests that rotations within one millisecond keep every backup
+func TestRotatingFileBackupCollision(t *testing.T) {
+	r, _ := openTestFile(t, RotationConfig{Compress: true}, 10)
+
+	writeEntries(t, r, "entry-1\n", "entry-2\n", "entry-3\n", "entry-4\n")
+	r.milling.Wait()
+
+	backups, err := r.backups()
+	require.NoError(t, err)
+	require.Len(t, backups, 3, "no backup is overwritten")
+	assert.Equal(t, filepath.Join(filepath.Dir(r.path), "app-2024-01-02T15-04-05.000.log.gz"), backups[0])
+	assert.Equal(t, filepath.Join(filepath.Dir(r.path), "app-2024-01-02T15-04-05.001.log.gz"), backups[1])
+	assert.Equal(t, "entry-1\n", readFile(t, backups[0]))
+	assert.Equal(t, "entry-2\n", readFile(t, backups[1]))
+	assert.Equal(t, "entry-3\n", readFile(t, backups[2]))
+	assert.Equal(t, "entry-4\n", readFile(t, r.path))
+}
@@ -0,0 +1,284 @@
+package logger
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// =============================================================================
+// SAMPLING AND RATE LIMITING
+// =============================================================================
+
+// SamplingConfig limits how many entries reach the outputs, so a hot loop cannot flood them
+// Sampling applies per message: of identical messages at the same level, the first Initial
+// per Interval are written and after that only every Thereafter-th. Rate limits cap the
+// total number of entries per level and Interval. Dropped entries are counted, see GetDropStats
+type SamplingConfig struct {
+	Interval       time.Duration         // Window for sampling and rate limits; 0 means one second
+	Initial        int                   // Identical messages written per interval before sampling; 0 disables sampling
+	Thereafter     int                   // After Initial, write every Thereafter-th identical message; 0 drops them all
+	RateLimits     map[zapcore.Level]int // Maximum entries per level and interval; levels not listed are unlimited
+	ReportInterval time.Duration         // How often dropped entries are reported as a warning; 0 disables reports
+}
+
+// DefaultSamplingConfig returns the sampling used by DefaultOptions, tuned for production:
+// 100 identical messages per second and every 100th after that, at most 1000 debug and
+// info and 500 warning entries per second, errors unlimited, and a drop report every minute
+func DefaultSamplingConfig() SamplingConfig {
+	return SamplingConfig{
+		Interval:   time.Second,
+		Initial:    100,
+		Thereafter: 100,
+		RateLimits: map[zapcore.Level]int{
+			zap.DebugLevel: 1000,
+			zap.InfoLevel:  1000,
+			zap.WarnLevel:  500,
+		},
+		ReportInterval: time.Minute,
+	}
+}
+
+// normalized returns a copy of the config with defaults filled in and the map copied
+func (c SamplingConfig) normalized() SamplingConfig {
+	if c.Interval <= 0 {
+		c.Interval = time.Second
+	}
+	if c.RateLimits != nil {
+		limits := make(map[zapcore.Level]int, len(c.RateLimits))
+		for level, limit := range c.RateLimits {
+			limits[level] = limit
+		}
+		c.RateLimits = limits
+	}
+	return c
+}
+
+// wrap applies sampling and rate limits in front of a core
+// Rate limits only see entries kept by sampling, so repeated messages do not use them up
+func (c SamplingConfig) wrap(core zapcore.Core) zapcore.Core {
+	if len(c.RateLimits) > 0 {
+		limited := &rateLimitCore{Core: core, limits: map[zapcore.Level]*rateWindow{}}
+		for level, limit := range c.RateLimits {
+			limited.limits[level] = &rateWindow{interval: c.Interval, limit: limit}
+		}
+		core = limited
+	}
+	if c.Initial > 0 {
+		core = zapcore.NewSamplerWithOptions(core, c.Interval, c.Initial, c.Thereafter,
+			zapcore.SamplerHook(func(ent zapcore.Entry, dec zapcore.SamplingDecision) {
+				if dec&zapcore.LogDropped != 0 {
+					dropCounters.sampled[levelIndex(ent.Level)].Add(1)
+				}
+			}))
+	}
+	return core
+}
+
+// rateLimitCore drops entries of a level once its limit for the current interval is reached
+type rateLimitCore struct {
+	zapcore.Core
+	limits map[zapcore.Level]*rateWindow // Shared with cores derived by With
+}
+
+// Check adds the wrapped core to the entry unless its level is over the limit
+func (c *rateLimitCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if window, ok := c.limits[ent.Level]; ok && !window.allow(ent.Time) {
+		dropCounters.rateLimited[levelIndex(ent.Level)].Add(1)
+		return ce
+	}
+	return c.Core.Check(ent, ce)
+}
+
+// With adds fields to the wrapped core, sharing the rate limits
+func (c *rateLimitCore) With(fields []zapcore.Field) zapcore.Core {
+	return &rateLimitCore{Core: c.Core.With(fields), limits: c.limits}
+}
+
+// rateWindow counts the entries of one level in fixed windows of the interval
+type rateWindow struct {
+	mu       sync.Mutex
+	interval time.Duration
+	limit    int
+	start    time.Time
+	count    int
+}
+
+// allow records an entry at t and reports whether it is within the limit
+func (w *rateWindow) allow(t time.Time) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if t.Sub(w.start) >= w.interval || t.Before(w.start) {
+		w.start, w.count = t, 0
+	}
+	w.count++
+	return w.count <= w.limit
+}
+
+// =============================================================================
+// DROP STATISTICS
+// =============================================================================
+
+// levelCount is the number of zap levels from debug to fatal
+const levelCount = int(zapcore.FatalLevel-zapcore.DebugLevel) + 1
+
+// levelIndex maps a level to its counter slot; levels outside debug to fatal share the fatal slot
+func levelIndex(level zapcore.Level) int {
+	if level < zapcore.DebugLevel || level > zapcore.FatalLevel {
+		return levelCount - 1
+	}
+	return int(level - zapcore.DebugLevel)
+}
+
+// dropCounters counts the entries dropped since the process started, per level
+// They survive rebuilds of the logger so the numbers never jump back
+var dropCounters struct {
+	sampled     [levelCount]atomic.Uint64
+	rateLimited [levelCount]atomic.Uint64
+	hooks       [levelCount]atomic.Uint64
+}
+
+// DropStats counts log entries dropped by sampling, rate limiting and hooks, by level name
+type DropStats struct {
+	Sampled     map[string]uint64 `json:"sampled,omitempty"`      // Dropped as repeats of an identical message
+	RateLimited map[string]uint64 `json:"rate_limited,omitempty"` // Dropped because the level was over its limit
+	Hooks       map[string]uint64 `json:"hooks,omitempty"`        // Not delivered by a hook, see AddHook
+	Total       uint64            `json:"total"`                  // All dropped entries
+}
+
+// GetDropStats returns the number of entries dropped since the process started
+func GetDropStats() DropStats {
+	var stats DropStats
+	for i := 0; i < levelCount; i++ {
+		name := (zapcore.DebugLevel + zapcore.Level(i)).String()
+		if n := dropCounters.sampled[i].Load(); n > 0 {
+			if stats.Sampled == nil {
+				stats.Sampled = map[string]uint64{}
+			}
+			stats.Sampled[name] = n
+			stats.Total += n
+		}
+		if n := dropCounters.rateLimited[i].Load(); n > 0 {
+			if stats.RateLimited == nil {
+				stats.RateLimited = map[string]uint64{}
+			}
+			stats.RateLimited[name] = n
+			stats.Total += n
+		}
+		if n := dropCounters.hooks[i].Load(); n > 0 {
+			if stats.Hooks == nil {
+				stats.Hooks = map[string]uint64{}
+			}
+			stats.Hooks[name] = n
+			stats.Total += n
+		}
+	}
+	return stats
+}
+
+// since returns the drops counted after an earlier snapshot
+func (s DropStats) since(earlier DropStats) DropStats {
+	delta := DropStats{Total: s.Total - earlier.Total}
+	for name, n := range s.Sampled {
+		if d := n - earlier.Sampled[name]; d > 0 {
+			if delta.Sampled == nil {
+				delta.Sampled = map[string]uint64{}
+			}
+			delta.Sampled[name] = d
+		}
+	}
+	for name, n := range s.RateLimited {
+		if d := n - earlier.RateLimited[name]; d > 0 {
+			if delta.RateLimited == nil {
+				delta.RateLimited = map[string]uint64{}
+			}
+			delta.RateLimited[name] = d
+		}
+	}
+	for name, n := range s.Hooks {
+		if d := n - earlier.Hooks[name]; d > 0 {
+			if delta.Hooks == nil {
+				delta.Hooks = map[string]uint64{}
+			}
+			delta.Hooks[name] = d
+		}
+	}
+	return delta
+}
+
+// =============================================================================
+// DROP REPORTS
+// =============================================================================
+
+// dropReporter periodically logs how many entries were dropped since its last report
+type dropReporter struct {
+	interval time.Duration
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+// reporter is the running drop reporter, nil if reports are disabled; guarded by configMu
+var reporter *dropReporter
+
+// startDropReporter runs the drop reporter with the interval of the options, restarting
+// it only if the interval changed; must be called with configMu held
+func startDropReporter(opts Options) {
+	var interval time.Duration
+	if opts.Sampling != nil {
+		interval = opts.Sampling.ReportInterval
+	}
+	if reporter != nil && reporter.interval == interval {
+		return
+	}
+	if reporter != nil {
+		close(reporter.stop)
+		<-reporter.done
+		reporter = nil
+	}
+	if interval <= 0 {
+		return
+	}
+
+	reporter = &dropReporter{interval: interval, stop: make(chan struct{}), done: make(chan struct{})}
+	go reporter.run(GetDropStats())
+}
+
+// run reports drops every interval until stopped, starting from the given counts
+func (r *dropReporter) run(last DropStats) {
+	defer close(r.done)
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.stop:
+			return
+		case <-ticker.C:
+			current := GetDropStats()
+			reportDrops(current.since(last), r.interval)
+			last = current
+		}
+	}
+}
+
+// reportDrops logs a warning with the drops of one report interval, if there were any
+// The report bypasses sampling and rate limits so it cannot be dropped itself
+func reportDrops(delta DropStats, interval time.Duration) {
+	if delta.Total == 0 {
+		return
+	}
+	logger := loadState().unsampled
+	if logger == nil {
+		return
+	}
+	logger.Warn("Log entries dropped by sampling, rate limits and hooks",
+		zap.Uint64("dropped", delta.Total),
+		zap.Any("sampled", delta.Sampled),
+		zap.Any("rate_limited", delta.RateLimited),
+		zap.Any("hooks", delta.Hooks),
+		zap.Duration("interval", interval),
+	)
+}
@@ -0,0 +1,116 @@
+package logger
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	errorcodes "github.com/BhaveshKaushal/base-lib/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// TestSampling tests that repeated identical messages are sampled and counted
+func TestSampling(t *testing.T) {
+	path := initializeForTest(t, LoggerConfig{AppName: "test-app", Sampling: &SamplingConfig{Interval: time.Hour, Initial: 3, Thereafter: 5}})
+	before := GetDropStats()
+
+	for i := 1; i <= 20; i++ {
+		Warn("hot loop warning", Fields{"iteration": i})
+	}
+	Warn("different warning", nil)
+
+	output := readLog(t, path)
+	assert.Equal(t, 6, strings.Count(output, "hot loop warning"), "messages 1-3, 8, 13 and 18 are kept")
+	for _, i := range []int{1, 2, 3, 8, 13, 18} {
+		assert.Contains(t, output, fmt.Sprintf(`"iteration":%d}`, i))
+	}
+	assert.Contains(t, output, "different warning", "messages are sampled independently")
+
+	delta := GetDropStats().since(before)
+	assert.Equal(t, uint64(14), delta.Total)
+	assert.Equal(t, map[string]uint64{"warn": 14}, delta.Sampled)
+	assert.Nil(t, delta.RateLimited)
+}
+
+// TestRateLimits tests per-level limits on the number of entries
+func TestRateLimits(t *testing.T) {
+	path := initializeForTest(t, LoggerConfig{AppName: "test-app", Sampling: &SamplingConfig{
+		Interval:   time.Hour,
+		RateLimits: map[zapcore.Level]int{zap.InfoLevel: 5},
+	}})
+	before := GetDropStats()
+
+	err := errorcodes.NewErrDefault(errorcodes.ErrCodeDBQuery, "query failed", "test-app")
+	for i := 1; i <= 10; i++ {
+		Info(fmt.Sprintf("info message %d", i), nil)
+		Error(fmt.Sprintf("error message %d", i), err, nil)
+	}
+
+	output := readLog(t, path)
+	assert.Equal(t, 5, strings.Count(output, "info message"))
+	assert.Equal(t, 10, strings.Count(output, "error message"), "levels without a limit are not limited")
+
+	delta := GetDropStats().since(before)
+	assert.Equal(t, map[string]uint64{"info": 5}, delta.RateLimited)
+	assert.Equal(t, uint64(5), delta.Total)
+}
+
+// TestRateWindow tests that rate limits start over in every interval
+func TestRateWindow(t *testing.T) {
+	window := &rateWindow{interval: time.Second, limit: 2}
+	start := time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC)
+
+	assert.True(t, window.allow(start))
+	assert.True(t, window.allow(start.Add(100*time.Millisecond)))
+	assert.False(t, window.allow(start.Add(900*time.Millisecond)))
+	assert.True(t, window.allow(start.Add(time.Second)), "a new interval starts over")
+	assert.True(t, window.allow(start.Add(1500*time.Millisecond)))
+	assert.False(t, window.allow(start.Add(1600*time.Millisecond)))
+}
+
+// TestDropReport tests the periodic warning about dropped entries
+func TestDropReport(t *testing.T) {
+	path := initializeForTest(t, LoggerConfig{AppName: "test-app", Sampling: &SamplingConfig{
+		Interval:       time.Hour,
+		RateLimits:     map[zapcore.Level]int{zap.WarnLevel: 1},
+		ReportInterval: 20 * time.Millisecond,
+	}})
+
+	for i := 1; i <= 3; i++ {
+		Warn(fmt.Sprintf("limited warning %d", i), nil)
+	}
+
+	assert.Eventually(t, func() bool {
+		return strings.Contains(readLog(t, path), "Log entries dropped")
+	}, time.Second, 10*time.Millisecond)
+
+	output := readLog(t, path)
+	assert.Equal(t, 1, strings.Count(output, "limited warning"))
+	assert.Contains(t, output, `"logger":"logger"`)
+	assert.Contains(t, output, `"dropped":2`)
+	assert.Contains(t, output, `"rate_limited":{"warn":2}`)
+	assert.Equal(t, 1, strings.Count(output, "Log entries dropped"), "intervals without drops are not reported")
+}
+
+// TestDefaultSamplingConfig tests the production defaults
+func TestDefaultSamplingConfig(t *testing.T) {
+	config := DefaultSamplingConfig()
+	assert.Equal(t, time.Second, config.Interval)
+	assert.Equal(t, 100, config.Initial)
+	assert.Equal(t, 100, config.Thereafter)
+	assert.NotContains(t, config.RateLimits, zap.ErrorLevel, "errors are never rate limited by default")
+	assert.Equal(t, time.Minute, config.ReportInterval)
+
+	opts := DefaultOptions()
+	require.NotNil(t, opts.Sampling)
+	assert.Equal(t, config, *opts.Sampling)
+
+	// Normalized options do not share the rate limits map
+	normalized := opts.normalized()
+	normalized.Sampling.RateLimits[zap.InfoLevel] = 1
+	assert.Equal(t, 1000, opts.Sampling.RateLimits[zap.InfoLevel])
+}
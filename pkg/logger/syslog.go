@@ -0,0 +1,151 @@
+package logger
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// =============================================================================
+// SYSLOG HOOK
+// =============================================================================
+
+// Syslog facilities for SyslogConfig.Facility, see RFC 5424
+const (
+	FacilityUser   = 1  // Generic user-level messages, the default
+	FacilityDaemon = 3  // System daemons
+	FacilityLocal0 = 16 // Local use 0; local1 to local7 follow
+)
+
+// SyslogConfig configures a SyslogHook
+type SyslogConfig struct {
+	Level      zapcore.Level // Minimum level of written entries
+	Facility   int           // Syslog facility, default FacilityUser
+	AppName    string        // APP-NAME of the messages; default the "app_name" field or "-"
+	Hostname   string        // HOSTNAME of the messages; default os.Hostname
+	BufferSize int           // Entries queued before new ones are dropped; default 1000
+}
+
+// SyslogHook writes entries as RFC 5424 syslog messages, one per line, on a background
+// goroutine; w is typically a connection to a syslog daemon, e.g. from net.Dial("udp", ...)
+// Entries are queued without blocking the caller; entries that do not fit into the queue
+// or cannot be written are dropped and counted in GetDropStats
+type SyslogHook struct {
+	config SyslogConfig
+	w      io.Writer
+	queue  *hookQueue
+}
+
+// NewSyslogHook returns a started hook writing to w
+// Register it with AddHook and Close it on shutdown to write the queued entries
+func NewSyslogHook(w io.Writer, config SyslogConfig) *SyslogHook {
+	if config.Facility <= 0 {
+		config.Facility = FacilityUser
+	}
+	if config.Hostname == "" {
+		config.Hostname, _ = os.Hostname()
+	}
+	if config.BufferSize <= 0 {
+		config.BufferSize = 1000
+	}
+	h := &SyslogHook{config: config, w: w}
+	h.queue = newHookQueue(config.BufferSize, 1, time.Second, h.write)
+	return h
+}
+
+// Level returns the minimum level of written entries
+func (h *SyslogHook) Level() zapcore.Level {
+	return h.config.Level
+}
+
+// Fire queues the entry, returning an error if the queue is full
+func (h *SyslogHook) Fire(entry HookEntry) error {
+	return h.queue.push(entry)
+}
+
+// Flush writes the queued entries and waits until they are written or dropped
+func (h *SyslogHook) Flush() {
+	h.queue.Flush()
+}
+
+// Close writes the queued entries and stops the hook; it does not close w
+func (h *SyslogHook) Close() error {
+	h.queue.Close()
+	return nil
+}
+
+// write writes the entries of a batch, dropping those that fail
+func (h *SyslogHook) write(batch []HookEntry) {
+	for _, entry := range batch {
+		if _, err := io.WriteString(h.w, h.format(entry)); err != nil {
+			countHookDrops(entry.Level, 1)
+		}
+	}
+}
+
+// format returns an entry as a syslog line:
+// <PRI>1 TIMESTAMP HOSTNAME APP-NAME PROCID MSGID - MSG
+// The message is followed by the fields as a JSON object; MSGID is the logger name
+func (h *SyslogHook) format(entry HookEntry) string {
+	appName := h.config.AppName
+	if appName == "" {
+		appName, _ = entry.Fields["app_name"].(string)
+	}
+
+	msg := entry.Message
+	if len(entry.Fields) > 0 {
+		if fields, err := json.Marshal(entry.Fields); err == nil {
+			msg += " " + string(fields)
+		}
+	}
+
+	return fmt.Sprintf("<%d>1 %s %s %s %d %s - %s\n",
+		h.config.Facility*8+syslogSeverity(entry.Level),
+		entry.Time.UTC().Format(time.RFC3339Nano),
+		syslogHeader(h.config.Hostname, 255),
+		syslogHeader(appName, 48),
+		os.Getpid(),
+		syslogHeader(entry.LoggerName, 32),
+		strings.ReplaceAll(msg, "\n", " "), // One message per line
+	)
+}
+
+// syslogSeverity maps a level to the syslog severity
+func syslogSeverity(level zapcore.Level) int {
+	switch level {
+	case zapcore.DebugLevel:
+		return 7 // Debug
+	case zapcore.InfoLevel:
+		return 6 // Informational
+	case zapcore.WarnLevel:
+		return 4 // Warning
+	case zapcore.ErrorLevel:
+		return 3 // Error
+	case zapcore.DPanicLevel, zapcore.PanicLevel:
+		return 2 // Critical
+	}
+	return 1 // Alert, for fatal entries
+}
+
+// syslogHeader returns a header field: printable ASCII without spaces, at most max
+// characters, and "-" if empty
+func syslogHeader(value string, max int) string {
+	value = strings.Map(func(r rune) rune {
+		if r <= ' ' || r > '~' {
+			return '_'
+		}
+		return r
+	}, value)
+	if value == "" {
+		return "-"
+	}
+	if len(value) > max {
+		value = value[:max]
+	}
+	return value
+}
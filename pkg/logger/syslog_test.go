@@ -0,0 +1,129 @@
+package logger
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// syncBuffer is a buffer safe for the hook's goroutine and the test
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+// Write appends to the buffer
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+// String returns the buffer content
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+// failingWriter fails every write
+type failingWriter struct{}
+
+// Write returns an error
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, fmt.Errorf("connection refused")
+}
+
+// TestSyslogHook tests the RFC 5424 format of written entries
+func TestSyslogHook(t *testing.T) {
+	at := time.Date(2024, 1, 2, 15, 4, 5, 123000000, time.UTC)
+	pid := os.Getpid()
+
+	tests := []struct {
+		name     string
+		config   SyslogConfig
+		entry    HookEntry
+		expected string
+	}{
+		{
+			name:   "info with fields",
+			config: SyslogConfig{Hostname: "web-1"},
+			entry: HookEntry{Time: at, Level: zap.InfoLevel, LoggerName: "payments", Message: "payment accepted",
+				Fields: Fields{"app_name": "shop", "amount": 42}},
+			expected: fmt.Sprintf(`<14>1 2024-01-02T15:04:05.123Z web-1 shop %d payments - payment accepted {"amount":42,"app_name":"shop"}`, pid),
+		},
+		{
+			name:     "error with facility and app name",
+			config:   SyslogConfig{Hostname: "web-1", Facility: FacilityLocal0, AppName: "billing"},
+			entry:    HookEntry{Time: at, Level: zap.ErrorLevel, Message: "charge failed"},
+			expected: fmt.Sprintf(`<131>1 2024-01-02T15:04:05.123Z web-1 billing %d - - charge failed`, pid),
+		},
+		{
+			name:     "sanitized header and multi-line message",
+			config:   SyslogConfig{Hostname: "my host"},
+			entry:    HookEntry{Time: at, Level: zap.WarnLevel, LoggerName: "jobs.ü", Message: "line one\nline two"},
+			expected: fmt.Sprintf(`<12>1 2024-01-02T15:04:05.123Z my_host - %d jobs._ - line one line two`, pid),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out syncBuffer
+			hook := NewSyslogHook(&out, tt.config)
+			require.NoError(t, hook.Fire(tt.entry))
+			require.NoError(t, hook.Close())
+			assert.Equal(t, tt.expected+"\n", out.String())
+		})
+	}
+}
+
+// TestSyslogHookLogging tests writing logged entries and counting failed writes
+func TestSyslogHookLogging(t *testing.T) {
+	initializeForTest(t, LoggerConfig{AppName: "test-app", Environment: "test"})
+
+	var out syncBuffer
+	hook := NewSyslogHook(&out, SyslogConfig{Level: zap.WarnLevel, Hostname: "web-1"})
+	remove := AddHook(hook)
+	Info("not written", nil)
+	Warn("disk almost full", nil)
+	hook.Flush()
+	remove()
+	require.NoError(t, hook.Close())
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Len(t, lines, 1)
+	assert.True(t, strings.HasPrefix(lines[0], "<12>1 "), lines[0])
+	assert.Contains(t, lines[0], " web-1 test-app ")
+	assert.Contains(t, lines[0], "disk almost full")
+
+	before := GetDropStats()
+	failing := NewSyslogHook(failingWriter{}, SyslogConfig{})
+	require.NoError(t, failing.Fire(HookEntry{Level: zap.ErrorLevel, Message: "lost"}))
+	require.NoError(t, failing.Close())
+	assert.Equal(t, map[string]uint64{"error": 1}, GetDropStats().since(before).Hooks)
+}
+
+// TestSyslogSeverity tests the mapping of levels to syslog severities
+func TestSyslogSeverity(t *testing.T) {
+	expected := map[zapcore.Level]int{
+		zap.DebugLevel:  7,
+		zap.InfoLevel:   6,
+		zap.WarnLevel:   4,
+		zap.ErrorLevel:  3,
+		zap.DPanicLevel: 2,
+		zap.PanicLevel:  2,
+		zap.FatalLevel:  1,
+	}
+	for level, severity := range expected {
+		assert.Equal(t, severity, syslogSeverity(level), level.String())
+	}
+}
@@ -0,0 +1,67 @@
+package logger
+
+import (
+	"context"
+
+	"github.com/BhaveshKaushal/base-lib/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap/zapcore"
+)
+
+// =============================================================================
+// OPENTELEMETRY CORRELATION
+// =============================================================================
+
+// Field names of the OpenTelemetry span written with every *Ctx entry, see spanFields
+// The trace ID uses TraceIDKey, so the span replaces an ID set with WithTraceID
+const (
+	SpanIDKey     = "span_id"     // Identifier of the active span
+	TraceFlagsKey = "trace_flags" // W3C trace flags of the active span, e.g. "01" if sampled
+)
+
+// spanFields returns the trace ID, span ID and trace flags of the span in ctx, nil if there is none
+func spanFields(ctx context.Context) Fields {
+	if ctx == nil {
+		return nil
+	}
+	spanContext := trace.SpanContextFromContext(ctx)
+	if !spanContext.IsValid() {
+		return nil
+	}
+	return Fields{
+		TraceIDKey:    spanContext.TraceID().String(),
+		SpanIDKey:     spanContext.SpanID().String(),
+		TraceFlagsKey: spanContext.TraceFlags().String(),
+	}
+}
+
+// SetSpanErrorRecording enables or disables recording logged errors on the span in the
+// context: ErrorCtx and LogErrCtx then add an exception event with the error code to the
+// span and set its status to error, for errors logged at error level or above
+func SetSpanErrorRecording(enabled bool) {
+	recordSpanErrors.Store(enabled)
+}
+
+// recordSpanError records an error logged at level on the span in ctx, if enabled and recording
+func recordSpanError(ctx context.Context, level zapcore.Level, msg string, err errors.Error) {
+	if ctx == nil || err == nil || level < zapcore.ErrorLevel || !recordSpanErrors.Load() {
+		return
+	}
+	span := trace.SpanFromContext(ctx)
+	if !span.IsRecording() {
+		return
+	}
+
+	code := err.Code()
+	if !errors.IsValidCode(code) {
+		code = errors.ErrCodeUnknown
+	}
+	span.RecordError(err, trace.WithAttributes(
+		attribute.String("error.code", string(code)),
+		attribute.String("error.category", code.Category().String()),
+		attribute.String("log.message", msg),
+	))
+	span.SetStatus(codes.Error, err.Message())
+}
@@ -0,0 +1,176 @@
+package logger
+
+import (
+	"context"
+	stderrors "errors"
+	"strings"
+	"testing"
+
+	errorcodes "github.com/BhaveshKaushal/base-lib/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// newTestTracer returns a tracer whose ended spans are kept in memory
+func newTestTracer(t *testing.T) (trace.Tracer, *tracetest.InMemoryExporter) {
+	t.Helper()
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	t.Cleanup(func() {
+		_ = provider.Shutdown(context.Background())
+	})
+	return provider.Tracer("logger-test"), exporter
+}
+
+// TestSpanCorrelation tests that entries logged with a span context carry its IDs
+func TestSpanCorrelation(t *testing.T) {
+	tracer, _ := newTestTracer(t)
+	core, logs := observer.New(zap.DebugLevel)
+	ctx, span := tracer.Start(ContextWithLogger(context.Background(), WithCore(core)), "checkout")
+	defer span.End()
+	spanContext := span.SpanContext()
+
+	InfoCtx(ctx, "order placed", Fields{"order_id": 7})
+	WithContext(ctx).Warn("zap entry")
+	InfoCtx(WithTraceID(ctx, "manual-id"), "span wins over manual trace id", nil)
+	InfoCtx(ctx, "call fields win", Fields{SpanIDKey: "explicit"})
+
+	entries := logs.All()
+	require.Len(t, entries, 4)
+	for _, entry := range entries[:3] {
+		fields := entry.ContextMap()
+		assert.Equal(t, spanContext.TraceID().String(), fields[TraceIDKey], entry.Message)
+		assert.Equal(t, spanContext.SpanID().String(), fields[SpanIDKey], entry.Message)
+		assert.Equal(t, "01", fields[TraceFlagsKey], entry.Message)
+	}
+	assert.Equal(t, "explicit", entries[3].ContextMap()[SpanIDKey])
+
+	// Contexts without a valid span add nothing
+	assert.Nil(t, spanFields(context.Background()))
+	assert.Nil(t, spanFields(nil)) //nolint:staticcheck // nil contexts are tolerated
+	logs.TakeAll()
+	InfoCtx(ContextWithLogger(context.Background(), WithCore(core)), "no span", nil)
+	assert.NotContains(t, logs.All()[0].ContextMap(), TraceIDKey)
+}
+
+// TestSpanErrorRecording tests recording logged errors on the span
+func TestSpanErrorRecording(t *testing.T) {
+	defer SetSpanErrorRecording(false)
+	core, _ := observer.New(zap.DebugLevel)
+	base := ContextWithLogger(context.Background(), WithCore(core))
+	dbErr := errorcodes.NewErrDefault(errorcodes.ErrCodeDBQuery, "query failed", "test-app")
+
+	tests := []struct {
+		name           string
+		enabled        bool
+		log            func(ctx context.Context)
+		expectedStatus codes.Code
+		expectedCode   string
+	}{
+		{
+			name:           "error",
+			enabled:        true,
+			log:            func(ctx context.Context) { ErrorCtx(ctx, "loading order", dbErr, nil) },
+			expectedStatus: codes.Error,
+			expectedCode:   string(errorcodes.ErrCodeDBQuery),
+		},
+		{
+			name:           "plain error",
+			enabled:        true,
+			log:            func(ctx context.Context) { LogErrCtx(ctx, "calling partner", stderrors.New("timeout"), nil) },
+			expectedStatus: codes.Error,
+			expectedCode:   string(errorcodes.ErrCodeUnknown),
+		},
+		{
+			name:    "severity below error",
+			enabled: true,
+			log: func(ctx context.Context) {
+				LogErrCtx(ctx, "order missing", errorcodes.NewErrDefault(errorcodes.ErrCodeNotFound, "no order", "test-app"), nil)
+			},
+			expectedStatus: codes.Unset,
+		},
+		{
+			name:           "disabled",
+			enabled:        false,
+			log:            func(ctx context.Context) { ErrorCtx(ctx, "loading order", dbErr, nil) },
+			expectedStatus: codes.Unset,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tracer, exporter := newTestTracer(t)
+			SetSpanErrorRecording(tt.enabled)
+
+			ctx, span := tracer.Start(base, "operation")
+			tt.log(ctx)
+			span.End()
+
+			spans := exporter.GetSpans()
+			require.Len(t, spans, 1)
+			assert.Equal(t, tt.expectedStatus, spans[0].Status.Code)
+			if tt.expectedCode == "" {
+				assert.Empty(t, spans[0].Events)
+				return
+			}
+
+			require.Len(t, spans[0].Events, 1)
+			event := spans[0].Events[0]
+			assert.Equal(t, "exception", event.Name)
+			attributes := attribute.NewSet(event.Attributes...)
+			code, _ := attributes.Value("error.code")
+			assert.Equal(t, tt.expectedCode, code.AsString())
+			message, _ := attributes.Value("log.message")
+			assert.NotEmpty(t, message.AsString())
+		})
+	}
+}
+
+// TestSpanErrorRecordingThrottled tests that spans and the error throttle only see written entries
+func TestSpanErrorRecordingThrottled(t *testing.T) {
+	path := initializeForTest(t, LoggerConfig{AppName: "test-app", ErrorThrottle: 3, RecordSpanErrors: true})
+	errorTracker.Reset()
+	defer errorTracker.Reset()
+	tracer, exporter := newTestTracer(t)
+	dbErr := errorcodes.NewErrDefault(errorcodes.ErrCodeDBQuery, "query failed", "test-app")
+
+	ctx, span := tracer.Start(context.Background(), "operation")
+	for i := 0; i < 5; i++ {
+		ErrorCtx(ctx, "loading order", dbErr, nil)
+	}
+
+	// Entries below the logger level are neither counted nor recorded
+	SetLogLevel("fatal")
+	ErrorCtx(ctx, "loading order", dbErr, nil)
+	LogErrCtx(ctx, "loading order", dbErr, nil)
+	SetLogLevel("info")
+	ErrorCtx(ctx, "loading order", dbErr, nil)
+	ErrorCtx(ctx, "loading order", dbErr, nil)
+	span.End()
+
+	lines := strings.Split(strings.TrimSpace(readLog(t, path)), "\n")
+	require.Len(t, lines, 3, "occurrences 1, 4 and 7 are written")
+	assert.Contains(t, lines[1], `"occurrences":4`)
+	assert.Contains(t, lines[2], `"occurrences":7`, "filtered entries do not count")
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 1)
+	assert.Len(t, spans[0].Events, 3, "one exception event per written entry")
+}
+
+// TestSpanErrorRecordingConfig tests enabling span error recording through Initialize
+func TestSpanErrorRecordingConfig(t *testing.T) {
+	restoreLogger(t)
+
+	Initialize(LoggerConfig{AppName: "test-app", RecordSpanErrors: true})
+	assert.True(t, recordSpanErrors.Load())
+	Initialize(LoggerConfig{AppName: "test-app"})
+	assert.False(t, recordSpanErrors.Load())
+}